@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/m1gwings/treedrawer/tree"
+)
+
+// readTree reads a tree from r in the given format.
+// If format is auto, the format is guessed from the extension of name and from the content of r.
+// Paths are split with sep and merged according to opts.
+func readTree(r io.Reader, name, format, sep string, opts tree.PathsOptions) (*tree.Tree, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading the tree: %v", err)
+	}
+	if format == "auto" {
+		format = detectFormat(name, b, sep)
+	}
+
+	var t *tree.Tree
+	switch format {
+	case "json":
+		t, err = tree.FromJSON(bytes.NewReader(b), tree.JSONOptions{})
+	case "indent":
+		t, err = tree.FromIndented(bytes.NewReader(b))
+	case "brackets":
+		t, err = tree.FromBrackets(bytes.NewReader(b))
+	case "paths":
+		var lines []string
+		lines, err = readLines(bytes.NewReader(b))
+		if err == nil {
+			t, err = tree.FromPaths(lines, sep, opts)
+		}
+	default:
+		return nil, fmt.Errorf("unknown input format %q, expected one of auto, json, indent, brackets, paths", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while reading the tree as %s: %v", format, err)
+	}
+	return t, nil
+}
+
+// detectFormat guesses the format of the tree in b, read from the file called name, given the separator sep of paths.
+// Files with the .json extension and content starting with [ or with { followed by " or } are JSON,
+// any other content starting with { is in bracket notation,
+// content without indented lines and with at least a line containing sep is a list of paths, everything else is indented.
+func detectFormat(name string, b []byte, sep string) string {
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		return "json"
+	}
+	s := strings.TrimLeftFunc(string(b), unicode.IsSpace)
+	switch {
+	case strings.HasPrefix(s, "["):
+		return "json"
+	case strings.HasPrefix(s, "{"):
+		rest := strings.TrimLeftFunc(s[1:], unicode.IsSpace)
+		if strings.HasPrefix(rest, "\"") || strings.HasPrefix(rest, "}") {
+			return "json"
+		}
+		return "brackets"
+	}
+	if isPaths(string(b), sep) {
+		return "paths"
+	}
+	return "indent"
+}
+
+// isPaths reports whether none of the non-empty lines of s is indented and at least one of them contains sep.
+func isPaths(s, sep string) bool {
+	found := false
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			return false
+		}
+		if sep != "" && strings.Contains(line, sep) {
+			found = true
+		}
+	}
+	return found
+}
+
+// readLines returns the lines of r without surrounding spaces, skipping empty ones.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,166 @@
+// Command treedrawer draws trees on the terminal.
+//
+// Usage:
+//
+//	treedrawer [flags] [file]
+//	treedrawer view [flags] [file]
+//
+// treedrawer reads a tree from file, or from the standard input if file is omitted,
+// and writes it to the standard output in the format chosen with -out.
+// The view command shows the tree in an interactive viewer instead.
+//
+// Run treedrawer -h to list the flags.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/m1gwings/treedrawer/drawer"
+	"github.com/m1gwings/treedrawer/tree"
+)
+
+// styles maps the values of the -style flag to the corresponding style.
+var styles = map[string]drawer.Style{
+	"rounded": drawer.RoundedStyle,
+	"square":  drawer.SquareStyle,
+	"heavy":   drawer.HeavyStyle,
+	"double":  drawer.DoubleStyle,
+	"ascii":   drawer.ASCIIStyle,
+}
+
+// orientations maps the values of the -orientation flag to the corresponding orientation.
+var orientations = map[string]tree.Orientation{
+	"down":  tree.TopDown,
+	"up":    tree.BottomUp,
+	"right": tree.LeftRight,
+	"left":  tree.RightLeft,
+}
+
+// config holds the values of the flags.
+type config struct {
+	in, out, sep       string
+	compress, counts   bool
+	trim               bool
+	style, orientation string
+	width              int
+	maxDepth           int
+	maxChildren        int
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if code := exitCode(err); code != 0 {
+		fmt.Fprintf(os.Stderr, "treedrawer: %v\n", err)
+		os.Exit(code)
+	}
+}
+
+// exitCode returns the status with which treedrawer exits after run returned err:
+// asking for help with -h is not a failure, even if run returns flag.ErrHelp.
+func exitCode(err error) int {
+	if err == nil || err == flag.ErrHelp {
+		return 0
+	}
+	return 1
+}
+
+// run executes treedrawer with the arguments args, reading the tree from stdin if no file is given.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	interactive := len(args) > 0 && args[0] == "view"
+	if interactive {
+		args = args[1:]
+	}
+
+	var c config
+	fs := flag.NewFlagSet("treedrawer", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: treedrawer [flags] [file]\n       treedrawer view [flags] [file]\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&c.in, "in", "auto", "input `format`: auto, json, indent, brackets or paths")
+	fs.StringVar(&c.out, "out", "box", "output `format`: box, outline, dot, svg or html")
+	fs.StringVar(&c.sep, "sep", "/", "`separator` of the components of paths")
+	fs.BoolVar(&c.compress, "compress", false, "join the components of paths with a single child")
+	fs.BoolVar(&c.counts, "counts", false, "label each leaf of paths with the number of paths ending in it")
+	fs.StringVar(&c.style, "style", "rounded", "`style` of boxes and lines: "+keys(styles))
+	fs.StringVar(&c.orientation, "orientation", "down", "`orientation` of the tree: "+keys(orientations))
+	fs.IntVar(&c.width, "width", 0, "maximum number of `columns` of the drawing, 0 means no limit")
+	fs.IntVar(&c.maxDepth, "max-depth", 0, "maximum number of `layers` drawn below the root, 0 means no limit")
+	fs.IntVar(&c.maxChildren, "max-children", 0, "maximum `number` of children drawn for each node, 0 means no limit")
+	fs.BoolVar(&c.trim, "trim", false, "remove the trailing spaces of each row of the drawing")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return fmt.Errorf("expected at most one file, received %d", fs.NArg())
+	}
+
+	opts, err := c.renderOptions()
+	if err != nil {
+		return err
+	}
+
+	in, name := stdin, ""
+	if fs.NArg() == 1 {
+		name = fs.Arg(0)
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	t, err := readTree(in, name, c.in, c.sep, tree.PathsOptions{Compress: c.compress, Counts: c.counts})
+	if err != nil {
+		return err
+	}
+
+	if interactive {
+		return view(t, opts)
+	}
+	return writeTree(stdout, t, c.out, opts)
+}
+
+// renderOptions returns the render options described by the flags.
+func (c config) renderOptions() (tree.RenderOptions, error) {
+	style, ok := styles[c.style]
+	if !ok {
+		return tree.RenderOptions{}, fmt.Errorf("unknown style %q, expected one of %s", c.style, keys(styles))
+	}
+	orientation, ok := orientations[c.orientation]
+	if !ok {
+		return tree.RenderOptions{}, fmt.Errorf("unknown orientation %q, expected one of %s", c.orientation, keys(orientations))
+	}
+	return tree.RenderOptions{
+		MaxDepth:    c.maxDepth,
+		MaxChildren: c.maxChildren,
+		MaxWidth:    c.width,
+		Style:       style,
+		Orientation: orientation,
+		Output:      drawer.OutputOptions{TrimTrailing: c.trim},
+	}, nil
+}
+
+// keys returns the keys of m, which must be a map with string keys, sorted and separated by commas.
+func keys(m interface{}) string {
+	var k []string
+	switch m := m.(type) {
+	case map[string]drawer.Style:
+		for key := range m {
+			k = append(k, key)
+		}
+	case map[string]tree.Orientation:
+		for key := range m {
+			k = append(k, key)
+		}
+	}
+	sort.Strings(k)
+	return strings.Join(k, ", ")
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		input string
+		want  string
+	}{
+		{"brackets outline", []string{"-out", "outline"}, "{a{b{c}}{d}}",
+			"a\n├── b\n│   ╰── c\n╰── d\n"},
+		{"json outline", []string{"-out", "outline"}, `{"k": [1]}`,
+			"{}\n╰── k\n    ╰── [0]: 1\n"},
+		{"paths outline", []string{"-in", "paths", "-out", "outline"}, "usr/bin\nusr/lib\n/etc\n",
+			"/\n├── usr\n│   ├── bin\n│   ╰── lib\n╰── etc\n"},
+		{"detected paths", []string{"-out", "outline"}, "a/b/c\na/b/d\na/e\n",
+			"/\n╰── a\n    ├── b\n    │   ├── c\n    │   ╰── d\n    ╰── e\n"},
+		{"compressed paths", []string{"-in", "paths", "-out", "outline", "-sep", ".", "-compress", "-counts"}, "a.b.c\n\na.b.c\n  d\n",
+			".\n├── a.b.c (2)\n╰── d (1)\n"},
+		{"trim", []string{"-style", "ascii", "-trim"}, "a\n b\n",
+			"+-+\n|a|\n+++\n |\n+++\n|b|\n+-+\n"},
+		{"dot", []string{"-out", "dot"}, "a\n b \"q\"\n",
+			"digraph tree {\n\tnode [shape=box, style=rounded, fontname=monospace];\n" +
+				"\tn0 [label=\"a\"];\n\tn1 [label=\"b \\\"q\\\"\"];\n\tn0 -> n1;\n}\n"},
+	}
+	for _, test := range tests {
+		var out bytes.Buffer
+		err := run(test.args, strings.NewReader(test.input), &out, ioutil.Discard)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if out.String() != test.want {
+			t.Errorf("%s: expected:\n%s\nreceived:\n%s", test.name, test.want, out.String())
+		}
+	}
+}
+
+func TestRunDrawings(t *testing.T) {
+	var box, svgOut, htmlOut bytes.Buffer
+	input := "root\n  <child>\n"
+	if err := run([]string{"-style", "ascii", "-orientation", "up"}, strings.NewReader(input), &box, ioutil.Discard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows := strings.Split(strings.TrimSuffix(box.String(), "\n"), "\n")
+	if !strings.Contains(rows[1], "<child>") || !strings.Contains(rows[len(rows)-2], "root") {
+		t.Errorf("expected the root at the bottom, received:\n%s", box.String())
+	}
+
+	if err := run([]string{"-out", "svg"}, strings.NewReader(input), &svgOut, ioutil.Discard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(svgOut.String(), "<svg ") || !strings.Contains(svgOut.String(), "&lt;child&gt;") {
+		t.Errorf("expected an SVG image with escaped text, received:\n%s", svgOut.String())
+	}
+
+	if err := run([]string{"-out", "html"}, strings.NewReader(input), &htmlOut, ioutil.Discard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(htmlOut.String(), "<pre") || !strings.Contains(htmlOut.String(), "&lt;child&gt;") {
+		t.Errorf("expected an HTML page with escaped text, received:\n%s", htmlOut.String())
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-in", "yaml"},
+		{"-out", "png"},
+		{"-style", "dotted"},
+		{"-orientation", "sideways"},
+		{"-width", "-1"},
+		{"a", "b"},
+	} {
+		err := run(args, strings.NewReader("a\n"), ioutil.Discard, ioutil.Discard)
+		if err == nil {
+			t.Errorf("%v: expected error, received nil", args)
+		}
+	}
+}
+
+func TestRunHelp(t *testing.T) {
+	for _, args := range [][]string{{"-h"}, {"-help"}, {"view", "-h"}} {
+		var stderr bytes.Buffer
+		err := run(args, strings.NewReader("a\n"), ioutil.Discard, &stderr)
+		if err != flag.ErrHelp {
+			t.Errorf("%v: expected flag.ErrHelp, received %v", args, err)
+		}
+		if code := exitCode(err); code != 0 {
+			t.Errorf("%v: expected exit status 0, received %d", args, code)
+		}
+		if !strings.HasPrefix(stderr.String(), "usage: treedrawer") {
+			t.Errorf("%v: expected the usage, received:\n%s", args, stderr.String())
+		}
+	}
+
+	if code := exitCode(nil); code != 0 {
+		t.Errorf("expected exit status 0 without errors, received %d", code)
+	}
+	if code := exitCode(run([]string{"-style", "dotted"}, strings.NewReader("a\n"), ioutil.Discard, ioutil.Discard)); code != 1 {
+		t.Errorf("expected exit status 1 for an invalid flag, received %d", code)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name, content, sep, want string
+	}{
+		{"tree.json", "a", "/", "json"},
+		{"TREE.JSON", "{a{b}}", "/", "json"},
+		{"", ` { "a": 1}`, "/", "json"},
+		{"", "{}", "/", "json"},
+		{"", "[1, 2]", "/", "json"},
+		{"", "{a{b}}", "/", "brackets"},
+		{"", "\n{ a }", "/", "brackets"},
+		{"", "a\n  b", "/", "indent"},
+		{"", "a", "/", "indent"},
+		{"", "a\nb", "/", "indent"},
+		{"", "a/b/c\na/b/d\na/e\n", "/", "paths"},
+		{"", "a/b\r\n\r\nc\r\n", "/", "paths"},
+		{"", "/etc\n/usr", "/", "paths"},
+		{"", "a.b\na.c", ".", "paths"},
+		{"", "a.b\na.c", "/", "indent"},
+		{"", "a/b\n  a/c", "/", "indent"},
+		{"", "a/b\n\ta/c", "/", "indent"},
+		{"", "  a/b\n  a/c", "/", "indent"},
+		{"", "a/b", "", "indent"},
+	}
+	for _, test := range tests {
+		if got := detectFormat(test.name, []byte(test.content), test.sep); got != test.want {
+			t.Errorf("%q %q with separator %q: expected %s, received %s", test.name, test.content, test.sep, test.want, got)
+		}
+	}
+}
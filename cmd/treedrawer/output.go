@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/m1gwings/treedrawer/tree"
+)
+
+// Dimensions of the characters of SVG drawings, in pixels.
+const (
+	svgCharWidth  = 9
+	svgLineHeight = 18
+	svgFontSize   = 15
+)
+
+// writeTree writes t to w in the given format, drawn with opts.
+// The dot format describes the whole tree and ignores opts.
+func writeTree(w io.Writer, t *tree.Tree, format string, opts tree.RenderOptions) error {
+	var s string
+	var err error
+	switch format {
+	case "box":
+		return tree.Fprint(w, t, opts)
+	case "svg", "html":
+		s, err = t.Render(opts)
+	case "outline":
+		s, err = t.Outline(opts)
+	case "dot":
+		s = dot(t)
+	default:
+		return fmt.Errorf("unknown output format %q, expected one of box, outline, dot, svg, html", format)
+	}
+	if err != nil {
+		return fmt.Errorf("error while drawing the tree: %v", err)
+	}
+
+	switch format {
+	case "svg":
+		s = svg(s)
+	case "html":
+		s = page(s)
+	}
+	_, err = io.WriteString(w, s)
+	return err
+}
+
+// dot returns the description of t in the DOT language of Graphviz, as a directed graph.
+func dot(t *tree.Tree) string {
+	var b strings.Builder
+	b.WriteString("digraph tree {\n\tnode [shape=box, style=rounded, fontname=monospace];\n")
+	ids := make(map[*tree.Tree]int)
+	t.Walk(func(n *tree.Tree) error {
+		ids[n] = len(ids)
+		fmt.Fprintf(&b, "\tn%d [label=\"%s\"];\n", ids[n], dotEscape(tree.Label(n.Val())))
+		if p, ok := n.Parent(); ok && n != t {
+			fmt.Fprintf(&b, "\tn%d -> n%d;\n", ids[p], ids[n])
+		}
+		return nil
+	})
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotEscape escapes s to be written inside a quoted DOT string, with each line of s on its own line.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// svg returns an SVG image with the text drawing s, each row written with a monospace font.
+func svg(s string) string {
+	rows := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	width := 0
+	for _, row := range rows {
+		if n := utf8.RuneCountInString(row); n > width {
+			width = n
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"monospace\" font-size=\"%d\">\n",
+		width*svgCharWidth, len(rows)*svgLineHeight, svgFontSize)
+	b.WriteString("<rect width=\"100%\" height=\"100%\" fill=\"white\"/>\n")
+	for i, row := range rows {
+		fmt.Fprintf(&b, "<text x=\"0\" y=\"%d\" xml:space=\"preserve\" textLength=\"%d\">%s</text>\n",
+			(i+1)*svgLineHeight-svgLineHeight/4, utf8.RuneCountInString(row)*svgCharWidth, html.EscapeString(row))
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// page returns a standalone HTML page showing the text drawing s.
+func page(s string) string {
+	return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>tree</title>\n</head>\n<body>\n" +
+		"<pre style=\"line-height: 1.2; font-family: monospace;\">\n" + html.EscapeString(s) + "</pre>\n</body>\n</html>\n"
+}
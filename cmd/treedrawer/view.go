@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/m1gwings/treedrawer/tree"
+	"github.com/m1gwings/treedrawer/viewer"
+)
+
+// view shows t in the interactive viewer, drawn according to opts.
+func view(t *tree.Tree, opts tree.RenderOptions) error {
+	v, err := viewer.New(t, opts)
+	if err != nil {
+		return err
+	}
+	// Keys are read from the terminal since the standard input may hold the tree
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("error while opening the terminal: %v", err)
+	}
+	defer tty.Close()
+	return v.Run(tty)
+}
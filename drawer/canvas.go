@@ -0,0 +1,152 @@
+package drawer
+
+import "fmt"
+
+// NewGrowingDrawer returns a new Drawer with width w and height h which grows to include
+// everything drawn outside of it, in any direction: drawing in negative positions
+// moves the up left corner of the canvas, so that the runes already drawn keep their positions.
+// Clipped variants of drawing methods never make the canvas grow.
+func NewGrowingDrawer(w, h int) (*Drawer, error) {
+	d, err := NewDrawer(w, h)
+	if err != nil {
+		return nil, err
+	}
+	d.growing = true
+	return d, nil
+}
+
+// Bounds returns the rectangle covered by the canvas,
+// its up left corner is in position 0, 0 unless a growing drawer has grown towards negative positions.
+func (d *Drawer) Bounds() Rect {
+	w, h := d.Dimens()
+	return Rect{X: -d.originX, Y: -d.originY, W: w, H: h}
+}
+
+// grow enlarges the canvas of a growing drawer so that it covers rect too,
+// it has no effect on other drawers or if rect is empty.
+func (d *Drawer) grow(rect Rect) {
+	if !d.growing || rect.W <= 0 || rect.H <= 0 {
+		return
+	}
+	b := d.Bounds()
+	u := b
+	if rect.X < u.X {
+		u.W, u.X = u.W+u.X-rect.X, rect.X
+	}
+	if rect.Y < u.Y {
+		u.H, u.Y = u.H+u.Y-rect.Y, rect.Y
+	}
+	if rect.X+rect.W > u.X+u.W {
+		u.W = rect.X + rect.W - u.X
+	}
+	if rect.Y+rect.H > u.Y+u.H {
+		u.H = rect.Y + rect.H - u.Y
+	}
+	if u == b {
+		return
+	}
+
+	canvas := make([][]rune, u.H)
+	for i := range canvas {
+		canvas[i] = make([]rune, u.W)
+	}
+	for i, row := range d.canvas {
+		copy(canvas[i+b.Y-u.Y][b.X-u.X:], row)
+	}
+	d.canvas, d.originX, d.originY = canvas, -u.X, -u.Y
+}
+
+// Crop reduces the canvas to the cells inside rect, the up left corner of rect becomes position 0, 0.
+// Returns an error if rect is not inside the canvas or if it is empty.
+func (d *Drawer) Crop(rect Rect) error {
+	if rect.W <= 0 || rect.H <= 0 {
+		return fmt.Errorf("width and height must be positive, received %d %d", rect.W, rect.H)
+	}
+	if !d.inside(rect.X, rect.Y) || !d.inside(rect.X+rect.W-1, rect.Y+rect.H-1) {
+		b := d.Bounds()
+		return fmt.Errorf("rectangle %+v is not inside the canvas %+v", rect, b)
+	}
+	canvas := make([][]rune, rect.H)
+	for i := range canvas {
+		canvas[i] = make([]rune, rect.W)
+		copy(canvas[i], d.canvas[rect.Y+d.originY+i][rect.X+d.originX:])
+	}
+	d.canvas, d.originX, d.originY = canvas, 0, 0
+	return nil
+}
+
+// Resize changes the dimensions of the canvas to width w and height h, keeping the up left corner where it is.
+// Cells beyond the new dimensions are discarded and new cells are transparent.
+// Returns an error if w or h are negative, like NewDrawer the canvas is at least 1 by 1.
+func (d *Drawer) Resize(w, h int) error {
+	resized, err := NewDrawer(w, h)
+	if err != nil {
+		return err
+	}
+	for i := range resized.canvas {
+		if i < len(d.canvas) {
+			copy(resized.canvas[i], d.canvas[i])
+		}
+	}
+	d.canvas = resized.canvas
+	return nil
+}
+
+// Trim removes the rows and columns made only of transparent cells from the borders of the canvas,
+// the up left corner of what remains becomes position 0, 0.
+// Spaces drawn explicitly are not removed.
+// Returns the rectangle which has been kept, in the positions before trimming,
+// which is empty if the whole canvas is transparent, in that case a single transparent cell is left.
+func (d *Drawer) Trim() Rect {
+	b := d.Bounds()
+	minX, minY, maxX, maxY := b.W, b.H, -1, -1
+	for y, row := range d.canvas {
+		for x, r := range row {
+			if r == 0 {
+				continue
+			}
+			if x < minX {
+				minX = x
+			}
+			if x > maxX {
+				maxX = x
+			}
+			if y < minY {
+				minY = y
+			}
+			if y > maxY {
+				maxY = y
+			}
+		}
+	}
+
+	if maxX < 0 {
+		d.canvas, d.originX, d.originY = [][]rune{{0}}, 0, 0
+		return Rect{X: b.X, Y: b.Y}
+	}
+	kept := Rect{X: b.X + minX, Y: b.Y + minY, W: maxX - minX + 1, H: maxY - minY + 1}
+	// kept is inside the canvas and not empty, so Crop can't fail
+	d.Crop(kept)
+	return kept
+}
+
+// SubDrawer returns a drawer which is a view of the cells of d inside rect:
+// what is drawn on one is visible on the other, the up left corner of rect is position 0, 0 of the view.
+// The view never grows and stops sharing the cells with d when d changes its dimensions
+// by growing, Crop, Resize or Trim.
+// Returns an error if rect is not inside the canvas or if it is empty.
+func (d *Drawer) SubDrawer(rect Rect) (*Drawer, error) {
+	if rect.W <= 0 || rect.H <= 0 {
+		return nil, fmt.Errorf("width and height must be positive, received %d %d", rect.W, rect.H)
+	}
+	if !d.inside(rect.X, rect.Y) || !d.inside(rect.X+rect.W-1, rect.Y+rect.H-1) {
+		return nil, fmt.Errorf("rectangle %+v is not inside the canvas %+v", rect, d.Bounds())
+	}
+	sub := &Drawer{canvas: make([][]rune, rect.H)}
+	for i := range sub.canvas {
+		start := rect.X + d.originX
+		// Limiting the capacity so that the rows of the view can never reach beyond rect
+		sub.canvas[i] = d.canvas[rect.Y+d.originY+i][start : start+rect.W : start+rect.W]
+	}
+	return sub, nil
+}
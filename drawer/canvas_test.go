@@ -0,0 +1,126 @@
+package drawer
+
+import "testing"
+
+func TestGrowingDrawer(t *testing.T) {
+	d, err := NewGrowingDrawer(0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.DrawRune('a', 2, 1); err != nil {
+		t.Fatalf("a growing drawer should grow instead of failing: %v", err)
+	}
+	if err := d.DrawText("b", -1, -1, AlignLeft); err != nil {
+		t.Fatalf("a growing drawer should grow instead of failing: %v", err)
+	}
+	if b := d.Bounds(); b != (Rect{X: -1, Y: -1, W: 4, H: 3}) {
+		t.Errorf("expected bounds from -1, -1 of 4 by 3, received %+v", b)
+	}
+	// Runes keep their positions after growing
+	if r, _ := d.Rune(2, 1); r != 'a' {
+		t.Errorf("expected a in position 2, 1, received %q", r)
+	}
+	if expected := "b   \n    \n   a\n"; d.String() != expected {
+		t.Errorf("expected %q, received %q", expected, d.String())
+	}
+
+	e, _ := NewDrawer(2, 1)
+	e.DrawRune('─', 0, 0)
+	e.DrawRune('─', 1, 0)
+	if err := d.DrawDrawerMode(e, 2, 2, MergeLines); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.HLineClipped(-5, -1, 3, '─')
+	if expected := "b    \n     \n   a \n   ──\n"; d.String() != expected {
+		t.Errorf("clipped variants should not grow, expected %q, received %q", expected, d.String())
+	}
+	if _, err := d.Rune(-2, 0); err == nil {
+		t.Errorf("expected error reading outside the canvas, received nil")
+	}
+
+	tiles, err := d.Tiles(5, 2, 0)
+	if err != nil || len(tiles) != 2 || tiles[0].String() == "" {
+		t.Fatalf("expected 2 tiles, received %d with error %v", len(tiles), err)
+	}
+	if expected := "b    \n     \n"; tiles[0].Drawer.String() != expected {
+		t.Errorf("tiles should start from the up left corner, expected %q, received %q", expected, tiles[0].Drawer.String())
+	}
+}
+
+func TestCropResizeTrim(t *testing.T) {
+	d, err := NewDrawer(5, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.DrawText("ab", 1, 1, AlignLeft)
+	d.DrawRune(' ', 3, 2)
+
+	if err := d.Crop(Rect{X: 4, Y: 0, W: 2, H: 1}); err == nil {
+		t.Errorf("expected error for a rectangle outside the canvas, received nil")
+	}
+	if err := d.Crop(Rect{X: 1, Y: 1, W: 0, H: 1}); err == nil {
+		t.Errorf("expected error for an empty rectangle, received nil")
+	}
+
+	kept := d.Trim()
+	if kept != (Rect{X: 1, Y: 1, W: 3, H: 2}) {
+		t.Errorf("the explicit space should be kept, received %+v", kept)
+	}
+	if expected := "ab \n   \n"; d.String() != expected {
+		t.Errorf("expected %q, received %q", expected, d.String())
+	}
+
+	if err := d.Resize(2, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "ab\n  \n  \n"; d.String() != expected {
+		t.Errorf("expected %q, received %q", expected, d.String())
+	}
+	if err := d.Resize(-1, 1); err == nil {
+		t.Errorf("expected error for negative width, received nil")
+	}
+
+	if err := d.Crop(Rect{X: 1, Y: 0, W: 1, H: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.String() != "b\n" {
+		t.Errorf("expected %q, received %q", "b\n", d.String())
+	}
+
+	empty, _ := NewGrowingDrawer(2, 2)
+	empty.DrawRune(0, -3, -3)
+	if kept := empty.Trim(); kept.W != 0 || kept.H != 0 {
+		t.Errorf("trimming a transparent canvas should keep nothing, received %+v", kept)
+	}
+	if w, h := empty.Dimens(); w != 1 || h != 1 {
+		t.Errorf("a trimmed transparent canvas should be 1 by 1, received %d by %d", w, h)
+	}
+}
+
+func TestSubDrawer(t *testing.T) {
+	d, err := NewDrawer(4, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sub, err := d.SubDrawer(Rect{X: 1, Y: 1, W: 2, H: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sub.Box(Rect{W: 2, H: 2}, SquareStyle); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sub.DrawRune('x', 2, 0); err == nil {
+		t.Errorf("the view shouldn't reach beyond its rectangle")
+	}
+	d.DrawRune('*', 2, 2)
+	if expected := "    \n ┌┐ \n └* \n"; d.String() != expected {
+		t.Errorf("expected %q, received %q", expected, d.String())
+	}
+	if expected := "┌┐\n└*\n"; sub.String() != expected {
+		t.Errorf("expected %q, received %q", expected, sub.String())
+	}
+
+	if _, err := d.SubDrawer(Rect{X: 3, Y: 0, W: 2, H: 1}); err == nil {
+		t.Errorf("expected error for a rectangle outside the canvas, received nil")
+	}
+}
@@ -0,0 +1,75 @@
+package drawer
+
+import "fmt"
+
+// Mode describes how the runes drawn onto a drawer are combined with the runes already there.
+type Mode int
+
+const (
+	// Overwrite replaces every cell, even with the transparent cells of the drawn canvas.
+	// It is the mode of DrawDrawer and DrawRune.
+	Overwrite Mode = iota
+	// SkipTransparent leaves untouched the cells below the transparent cells of the drawn canvas.
+	SkipTransparent
+	// MergeLines skips transparent cells like SkipTransparent
+	// and merges box-drawing runes with the ones below them as MergeRunes does,
+	// so that '─' drawn over '│' becomes '┼'.
+	MergeLines
+)
+
+// combine returns the rune of a cell holding below after drawing above onto it in mode.
+func (m Mode) combine(below, above rune) rune {
+	switch {
+	case m == Overwrite:
+		return above
+	case above == 0:
+		return below
+	case m == MergeLines:
+		return MergeRunes(below, above)
+	}
+	return above
+}
+
+// valid reports whether m is one of the defined modes.
+func (m Mode) valid() bool {
+	return m == Overwrite || m == SkipTransparent || m == MergeLines
+}
+
+// DrawRuneMode draws a rune in position x, y in the drawer canvas, combined with the rune already there according to mode.
+// Returns an error if the x, y position in input is outside the canvas, where a growing drawer grows instead,
+// or if mode is unknown.
+func (d *Drawer) DrawRuneMode(r rune, x, y int, mode Mode) error {
+	if !mode.valid() {
+		return fmt.Errorf("unknown mode %d", mode)
+	}
+	d.grow(Rect{X: x, Y: y, W: 1, H: 1})
+	below, err := d.Rune(x, y)
+	if err != nil {
+		return err
+	}
+	*d.cell(x, y) = mode.combine(below, r)
+	return nil
+}
+
+// DrawDrawerMode draws the canvas inside e onto d with the up left corner in position x, y,
+// combining each rune of e with the rune below it according to mode.
+// Returns an error if the canvas inside e, drawn in position x, y, overflows the canvas in d,
+// where a growing drawer grows instead, or if mode is unknown.
+func (d *Drawer) DrawDrawerMode(e *Drawer, x, y int, mode Mode) error {
+	if !mode.valid() {
+		return fmt.Errorf("unknown mode %d", mode)
+	}
+	eW, eH := e.Dimens()
+	d.grow(Rect{X: x, Y: y, W: eW, H: eH})
+	if !d.inside(x, y) || !d.inside(x+eW-1, y+eH-1) {
+		w, h := d.Dimens()
+		return fmt.Errorf("canvas e of dimension (%d, %d) drawn in position (%d, %d) overflows canvas d of dimension (%d, %d)", eW, eH, x, y, w, h)
+	}
+	for i, row := range e.canvas {
+		for j, r := range row {
+			c := d.cell(j+x, i+y)
+			*c = mode.combine(*c, r)
+		}
+	}
+	return nil
+}
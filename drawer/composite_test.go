@@ -0,0 +1,120 @@
+package drawer
+
+import "testing"
+
+func TestMergeRunes(t *testing.T) {
+	tests := []struct {
+		below, above, expected rune
+	}{
+		{'│', '─', '┼'},
+		{'─', '│', '┼'},
+		{'┬', '┴', '┼'},
+		{'─', '╮', '┬'},
+		{'╭', '╮', '┬'},
+		{'╰', '│', '├'},
+		{'│', '╷', '│'},
+		{'┼', '─', '┼'},
+		{'╭', '╭', '╭'},
+		{'│', '━', '┿'},
+		{'━', '│', '┿'},
+		{'┃', '─', '╂'},
+		{'║', '═', '╬'},
+		{'║', '─', '╫'},
+		{'┃', '═', '═'},
+		{'a', '─', '─'},
+		{'─', 'a', 'a'},
+		{'-', '|', '+'},
+		{'+', '-', '+'},
+		{'-', '-', '-'},
+	}
+	for _, test := range tests {
+		if r := MergeRunes(test.below, test.above); r != test.expected {
+			t.Errorf("%c over %c: expected %c, received %c", test.above, test.below, test.expected, r)
+		}
+	}
+}
+
+func TestDrawDrawerMode(t *testing.T) {
+	// A vertical line in the middle of a 3 by 3 canvas, with an 'x' in the top left corner
+	newCanvas := func() *Drawer {
+		d, err := NewDrawer(3, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		d.DrawRune('x', 0, 0)
+		for y := 0; y < 3; y++ {
+			d.DrawRune('│', 1, y)
+		}
+		return d
+	}
+	// A horizontal line in the second row of a transparent 3 by 2 canvas
+	e, err := NewDrawer(3, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for x := 0; x < 3; x++ {
+		e.DrawRune('─', x, 1)
+	}
+
+	tests := []struct {
+		mode     Mode
+		expected string
+	}{
+		{Overwrite, "x│ \n   \n───\n"},
+		{SkipTransparent, "x│ \n │ \n───\n"},
+		{MergeLines, "x│ \n │ \n─┼─\n"},
+	}
+	for _, test := range tests {
+		d := newCanvas()
+		err := d.DrawDrawerMode(e, 0, 1, test.mode)
+		if err != nil {
+			t.Fatalf("mode %d: unexpected error: %v", test.mode, err)
+		}
+		if d.String() != test.expected {
+			t.Errorf("mode %d: expected %q, received %q", test.mode, test.expected, d.String())
+		}
+	}
+
+	d := newCanvas()
+	if err := d.DrawDrawerMode(e, 0, 2, MergeLines); err == nil {
+		t.Errorf("expected error for overflowing canvas, received nil")
+	}
+	if err := d.DrawDrawerMode(e, 0, 0, Mode(7)); err == nil {
+		t.Errorf("expected error for unknown mode, received nil")
+	}
+
+	if err := d.DrawRuneMode('─', 1, 1, MergeLines); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.DrawRuneMode(0, 0, 0, SkipTransparent); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.String() != "x│ \n ┼ \n │ \n" {
+		t.Errorf("expected the merged rune in the centre, received %q", d.String())
+	}
+	if err := d.DrawRuneMode('─', 3, 0, MergeLines); err == nil {
+		t.Errorf("expected error for rune outside the canvas, received nil")
+	}
+}
+
+func TestMergeEnd(t *testing.T) {
+	tests := []struct {
+		below, above rune
+		outward      int
+		expected     rune
+	}{
+		{'│', '─', left, '├'},
+		{'│', '─', right, '┤'},
+		{'─', '│', up, '┬'},
+		{'─', '│', down, '┴'},
+		{'╮', '│', up, '╮'},
+		{0, '─', left, '─'},
+		{'a', '─', left, '─'},
+		{'|', '-', left, '+'},
+	}
+	for _, test := range tests {
+		if r := mergeEnd(test.below, test.above, test.outward); r != test.expected {
+			t.Errorf("%c over %c open towards %d: expected %c, received %c", test.above, test.below, test.outward, test.expected, r)
+		}
+	}
+}
@@ -1,10 +1,18 @@
 package drawer
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 // Drawer is a canvas on which you can draw unicode runes.
 type Drawer struct {
 	canvas [][]rune
+	// originX and originY are the indexes in canvas of the cell in position 0, 0,
+	// they are not 0 only for growing drawers which have grown towards negative positions
+	originX, originY int
+	// growing reports whether the canvas grows to include what is drawn outside of it
+	growing bool
 }
 
 // NewDrawer returns a new Drawer with width w and height h.
@@ -30,30 +38,54 @@ func NewDrawer(w, h int) (*Drawer, error) {
 }
 
 // DrawRune draws a rune in position x, y in the drawer canvas.
-// Returns an error if the x, y position in input is outside the canvas.
+// Returns an error if the x, y position in input is outside the canvas,
+// a growing drawer grows to include it instead.
 func (d *Drawer) DrawRune(r rune, x, y int) error {
-	w, h := d.Dimens()
-	if x >= w || y >= h || x < 0 || y < 0 {
-		return fmt.Errorf("position (%d, %d) is outside the canvas of dimension (%d, %d)", x, y, w, h)
+	d.grow(Rect{X: x, Y: y, W: 1, H: 1})
+	if !d.inside(x, y) {
+		return d.outsideError(x, y)
 	}
-	d.canvas[y][x] = r
+	*d.cell(x, y) = r
 	return nil
 }
 
+// Rune returns the rune in position x, y in the drawer canvas, 0 if nothing has been drawn there.
+// Returns an error if the x, y position in input is outside the canvas.
+func (d *Drawer) Rune(x, y int) (rune, error) {
+	if !d.inside(x, y) {
+		return 0, d.outsideError(x, y)
+	}
+	return *d.cell(x, y), nil
+}
+
+// inside reports whether position x, y is inside the canvas.
+func (d *Drawer) inside(x, y int) bool {
+	w, h := d.Dimens()
+	x, y = x+d.originX, y+d.originY
+	return x >= 0 && y >= 0 && x < w && y < h
+}
+
+// cell returns a pointer to the rune in position x, y, which must be inside the canvas.
+func (d *Drawer) cell(x, y int) *rune {
+	return &d.canvas[y+d.originY][x+d.originX]
+}
+
+// outsideError returns the error for position x, y which is outside the canvas.
+func (d *Drawer) outsideError(x, y int) error {
+	if d.originX != 0 || d.originY != 0 {
+		b := d.Bounds()
+		return fmt.Errorf("position (%d, %d) is outside the canvas from (%d, %d) of dimension (%d, %d)", x, y, b.X, b.Y, b.W, b.H)
+	}
+	w, h := d.Dimens()
+	return fmt.Errorf("position (%d, %d) is outside the canvas of dimension (%d, %d)", x, y, w, h)
+}
+
 // DrawDrawer draws the canvas inside e onto d with the up left corner in position x, y.
+// Every cell is overwritten, including the ones below the transparent cells of e,
+// use DrawDrawerMode to choose how the canvases are combined.
 // Returns an error if the canvas inside e, drawn in position x, y, overflows the canvas in d.
 func (d *Drawer) DrawDrawer(e *Drawer, x, y int) error {
-	w, h := d.Dimens()
-	eW, eH := e.Dimens()
-	if x+eW-1 >= w || y+eH-1 >= h || x < 0 || y < 0 {
-		return fmt.Errorf("canvas e of dimension (%d, %d) drawn in position (%d, %d) overflows canvas d of dimension (%d, %d)", eW, eH, x, y, w, h)
-	}
-	for i, row := range e.canvas {
-		for j, r := range row {
-			d.canvas[i+y][j+x] = r
-		}
-	}
-	return nil
+	return d.DrawDrawerMode(e, x, y, Overwrite)
 }
 
 // Dimens returns width and height of the canvas.
@@ -64,16 +96,13 @@ func (d *Drawer) Dimens() (w, h int) {
 
 // String returns the string representation of the canvas.
 func (d *Drawer) String() string {
-	var s string
-	for _, row := range d.canvas {
-		for _, b := range row {
-			if b == 0 {
-				s += " "
-			} else {
-				s += string(b)
-			}
-		}
-		s += "\n"
-	}
-	return s
+	return d.Sprint(OutputOptions{})
+}
+
+// WriteTo writes the string representation of the canvas to w, a row at a time through a buffer,
+// without building the whole string in memory.
+// Returns the number of bytes written to w and the first error encountered while writing.
+// It satisfies the io.WriterTo interface.
+func (d *Drawer) WriteTo(w io.Writer) (n int64, err error) {
+	return d.Fprint(w, OutputOptions{})
 }
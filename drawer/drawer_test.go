@@ -1,6 +1,8 @@
 package drawer
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -108,3 +110,72 @@ func TestDrawDrawer(t *testing.T) {
 	//
 	//
 }
+
+func TestRune(t *testing.T) {
+	d, err := NewDrawer(3, 2)
+	if err != nil {
+		t.Errorf("you should be able to create a drawer with positive height and width: %v", err)
+	}
+	d.DrawRune('🌳', 2, 1)
+	r, err := d.Rune(2, 1)
+	if err != nil || r != '🌳' {
+		t.Errorf("expected 🌳 in position 2 1, received %c %v", r, err)
+	}
+	r, err = d.Rune(0, 0)
+	if err != nil || r != 0 {
+		t.Errorf("expected empty cell in position 0 0, received %c %v", r, err)
+	}
+	_, err = d.Rune(3, 0)
+	if err == nil {
+		t.Errorf("3 0 shouldn't be a valid position for reading a rune in this drawer")
+	}
+}
+
+// failingWriter accepts n bytes and then fails.
+type failingWriter struct {
+	n int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > f.n {
+		written := f.n
+		f.n = 0
+		return written, errors.New("failing writer is full")
+	}
+	f.n -= len(p)
+	return len(p), nil
+}
+
+func TestWriteTo(t *testing.T) {
+	d, err := NewDrawer(3, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.DrawRune('╭', 0, 0)
+	d.DrawRune('a', 2, 1)
+
+	var b bytes.Buffer
+	n, err := d.WriteTo(&b)
+	expected := "╭  \n  a\n"
+	if err != nil || b.String() != expected || n != int64(len(expected)) {
+		t.Errorf("expected %q of %d bytes, received %q of %d bytes with error %v", expected, len(expected), b.String(), n, err)
+	}
+	if d.String() != expected {
+		t.Errorf("String should match WriteTo, received %q", d.String())
+	}
+
+	n, err = d.WriteTo(&failingWriter{n: 4})
+	if err == nil || n != 4 {
+		t.Errorf("expected error after 4 bytes, received %d bytes with error %v", n, err)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	d, err := NewDrawer(400, 400)
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < b.N; i++ {
+		_ = d.String()
+	}
+}
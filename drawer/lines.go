@@ -0,0 +1,124 @@
+package drawer
+
+// arms describes the lines leaving the centre of a box-drawing rune,
+// as the weight of the up, right, down and left arms, in this order:
+// ' ' means no arm, 'L' a light arm, 'H' a heavy arm and 'D' a double arm.
+type arms [4]byte
+
+// Directions of the arms, used as indexes of arms.
+const (
+	up = iota
+	right
+	down
+	left
+)
+
+// lineArms maps each box-drawing rune made of straight lines to its arms,
+// dashed lines and diagonals are not included.
+var lineArms = map[rune]arms{
+	'─': {' ', 'L', ' ', 'L'}, '━': {' ', 'H', ' ', 'H'}, '│': {'L', ' ', 'L', ' '}, '┃': {'H', ' ', 'H', ' '},
+	'┌': {' ', 'L', 'L', ' '}, '┍': {' ', 'H', 'L', ' '}, '┎': {' ', 'L', 'H', ' '}, '┏': {' ', 'H', 'H', ' '},
+	'┐': {' ', ' ', 'L', 'L'}, '┑': {' ', ' ', 'L', 'H'}, '┒': {' ', ' ', 'H', 'L'}, '┓': {' ', ' ', 'H', 'H'},
+	'└': {'L', 'L', ' ', ' '}, '┕': {'L', 'H', ' ', ' '}, '┖': {'H', 'L', ' ', ' '}, '┗': {'H', 'H', ' ', ' '},
+	'┘': {'L', ' ', ' ', 'L'}, '┙': {'L', ' ', ' ', 'H'}, '┚': {'H', ' ', ' ', 'L'}, '┛': {'H', ' ', ' ', 'H'},
+	'├': {'L', 'L', 'L', ' '}, '┝': {'L', 'H', 'L', ' '}, '┞': {'H', 'L', 'L', ' '}, '┟': {'L', 'L', 'H', ' '},
+	'┠': {'H', 'L', 'H', ' '}, '┡': {'H', 'H', 'L', ' '}, '┢': {'L', 'H', 'H', ' '}, '┣': {'H', 'H', 'H', ' '},
+	'┤': {'L', ' ', 'L', 'L'}, '┥': {'L', ' ', 'L', 'H'}, '┦': {'H', ' ', 'L', 'L'}, '┧': {'L', ' ', 'H', 'L'},
+	'┨': {'H', ' ', 'H', 'L'}, '┩': {'H', ' ', 'L', 'H'}, '┪': {'L', ' ', 'H', 'H'}, '┫': {'H', ' ', 'H', 'H'},
+	'┬': {' ', 'L', 'L', 'L'}, '┭': {' ', 'L', 'L', 'H'}, '┮': {' ', 'H', 'L', 'L'}, '┯': {' ', 'H', 'L', 'H'},
+	'┰': {' ', 'L', 'H', 'L'}, '┱': {' ', 'L', 'H', 'H'}, '┲': {' ', 'H', 'H', 'L'}, '┳': {' ', 'H', 'H', 'H'},
+	'┴': {'L', 'L', ' ', 'L'}, '┵': {'L', 'L', ' ', 'H'}, '┶': {'L', 'H', ' ', 'L'}, '┷': {'L', 'H', ' ', 'H'},
+	'┸': {'H', 'L', ' ', 'L'}, '┹': {'H', 'L', ' ', 'H'}, '┺': {'H', 'H', ' ', 'L'}, '┻': {'H', 'H', ' ', 'H'},
+	'┼': {'L', 'L', 'L', 'L'}, '┽': {'L', 'L', 'L', 'H'}, '┾': {'L', 'H', 'L', 'L'}, '┿': {'L', 'H', 'L', 'H'},
+	'╀': {'H', 'L', 'L', 'L'}, '╁': {'L', 'L', 'H', 'L'}, '╂': {'H', 'L', 'H', 'L'}, '╃': {'H', 'L', 'L', 'H'},
+	'╄': {'H', 'H', 'L', 'L'}, '╅': {'L', 'L', 'H', 'H'}, '╆': {'L', 'H', 'H', 'L'}, '╇': {'H', 'H', 'L', 'H'},
+	'╈': {'L', 'H', 'H', 'H'}, '╉': {'H', 'L', 'H', 'H'}, '╊': {'H', 'H', 'H', 'L'}, '╋': {'H', 'H', 'H', 'H'},
+	'═': {' ', 'D', ' ', 'D'}, '║': {'D', ' ', 'D', ' '},
+	'╒': {' ', 'D', 'L', ' '}, '╓': {' ', 'L', 'D', ' '}, '╔': {' ', 'D', 'D', ' '},
+	'╕': {' ', ' ', 'L', 'D'}, '╖': {' ', ' ', 'D', 'L'}, '╗': {' ', ' ', 'D', 'D'},
+	'╘': {'L', 'D', ' ', ' '}, '╙': {'D', 'L', ' ', ' '}, '╚': {'D', 'D', ' ', ' '},
+	'╛': {'L', ' ', ' ', 'D'}, '╜': {'D', ' ', ' ', 'L'}, '╝': {'D', ' ', ' ', 'D'},
+	'╞': {'L', 'D', 'L', ' '}, '╟': {'D', 'L', 'D', ' '}, '╠': {'D', 'D', 'D', ' '},
+	'╡': {'L', ' ', 'L', 'D'}, '╢': {'D', ' ', 'D', 'L'}, '╣': {'D', ' ', 'D', 'D'},
+	'╤': {' ', 'D', 'L', 'D'}, '╥': {' ', 'L', 'D', 'L'}, '╦': {' ', 'D', 'D', 'D'},
+	'╧': {'L', 'D', ' ', 'D'}, '╨': {'D', 'L', ' ', 'L'}, '╩': {'D', 'D', ' ', 'D'},
+	'╪': {'L', 'D', 'L', 'D'}, '╫': {'D', 'L', 'D', 'L'}, '╬': {'D', 'D', 'D', 'D'},
+	'╭': {' ', 'L', 'L', ' '}, '╮': {' ', ' ', 'L', 'L'}, '╯': {'L', ' ', ' ', 'L'}, '╰': {'L', 'L', ' ', ' '},
+	'╴': {' ', ' ', ' ', 'L'}, '╵': {'L', ' ', ' ', ' '}, '╶': {' ', 'L', ' ', ' '}, '╷': {' ', ' ', 'L', ' '},
+	'╸': {' ', ' ', ' ', 'H'}, '╹': {'H', ' ', ' ', ' '}, '╺': {' ', 'H', ' ', ' '}, '╻': {' ', ' ', 'H', ' '},
+	'╼': {' ', 'H', ' ', 'L'}, '╽': {'L', ' ', 'H', ' '}, '╾': {' ', 'L', ' ', 'H'}, '╿': {'H', ' ', 'L', ' '},
+}
+
+// armsLine is the inverse of lineArms, rounded corners are left out so that they map to square corners.
+var armsLine = make(map[arms]rune, len(lineArms))
+
+func init() {
+	for r, a := range lineArms {
+		switch r {
+		case '╭', '╮', '╯', '╰':
+			continue
+		}
+		armsLine[a] = r
+	}
+}
+
+// asciiLines are the ASCII runes used to draw lines, any two different ones merge into '+'.
+var asciiLines = map[rune]bool{'-': true, '|': true, '+': true}
+
+// MergeRunes returns the rune which looks like above drawn over below.
+// If both are box-drawing runes made of straight lines, the result has the arms of both,
+// like '┼' for '─' over '│', where an arm is in both runes the weight of above wins.
+// Two different ASCII line runes among '-', '|' and '+' merge into '+'.
+// In every other case, or if there is no rune with the merged arms, like for heavy and double lines, above is returned.
+func MergeRunes(below, above rune) rune {
+	if asciiLines[below] && asciiLines[above] && below != above {
+		return '+'
+	}
+	aboveArms, ok := lineArms[above]
+	if !ok {
+		return above
+	}
+	return mergeArms(below, above, aboveArms)
+}
+
+// mergeEnd returns the rune which looks like above drawn over below at the end of a line,
+// where the arm of above in direction outward is dropped if below is a box-drawing rune,
+// so that a line ending on another line joins it without crossing it.
+func mergeEnd(below, above rune, outward int) rune {
+	if asciiLines[below] && asciiLines[above] && below != above {
+		return '+'
+	}
+	aboveArms, ok := lineArms[above]
+	if !ok {
+		return above
+	}
+	aboveArms[outward] = ' '
+	return mergeArms(below, above, aboveArms)
+}
+
+// mergeArms returns the rune with aboveArms together with the arms of below,
+// where an arm is in both the weight of aboveArms wins,
+// above is the rune drawn over below and the result when below is not a box-drawing rune.
+func mergeArms(below, above rune, aboveArms arms) rune {
+	belowArms, ok := lineArms[below]
+	if !ok {
+		return above
+	}
+	merged := aboveArms
+	for direction, weight := range merged {
+		if weight == ' ' {
+			merged[direction] = belowArms[direction]
+		}
+	}
+	// Keeping the original runes when nothing has been added, to preserve rounded corners
+	switch merged {
+	case lineArms[above]:
+		return above
+	case belowArms:
+		return below
+	}
+	if r, ok := armsLine[merged]; ok {
+		return r
+	}
+	return above
+}
@@ -0,0 +1,107 @@
+package drawer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// OutputOptions controls how the canvas is converted to text by Fprint and Sprint.
+// The zero value writes every row padded to the width of the canvas and terminated by a newline,
+// exactly like String does.
+type OutputOptions struct {
+	// Transparent is the rune written for transparent cells, on which nothing has been drawn,
+	// to tell them apart from spaces drawn explicitly. The zero value means ' '.
+	Transparent rune
+	// TrimTrailing removes transparent cells and spaces at the end of each row.
+	TrimTrailing bool
+	// CollapseBlankRows replaces each run of consecutive blank rows,
+	// made only of transparent cells and spaces, with a single row.
+	CollapseBlankRows bool
+	// NoFinalNewline omits the newline after the last row written, which may be a collapsed blank row.
+	NoFinalNewline bool
+}
+
+// Sprint returns the text representation of the canvas according to opts.
+func (d *Drawer) Sprint(opts OutputOptions) string {
+	var b strings.Builder
+	w, h := d.Dimens()
+	b.Grow((w + 1) * h)
+	// Writing to a strings.Builder never fails
+	d.Fprint(&b, opts)
+	return b.String()
+}
+
+// Fprint writes the text representation of the canvas to w according to opts,
+// a row at a time through a buffer, without building the whole string in memory.
+// Returns the number of bytes written to w and the first error encountered while writing.
+func (d *Drawer) Fprint(w io.Writer, opts OutputOptions) (n int64, err error) {
+	transparent := opts.Transparent
+	if transparent == 0 {
+		transparent = ' '
+	}
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	previousBlank := false
+	// Rows are separated by the newline written before each of them but the first,
+	// so that rows skipped at the end don't leave a newline behind
+	written := 0
+	for _, row := range d.canvas {
+		end := len(row)
+		if opts.TrimTrailing || opts.CollapseBlankRows {
+			end = blankSuffix(row)
+		}
+		if opts.CollapseBlankRows {
+			blank := end == 0
+			if blank && previousBlank {
+				continue
+			}
+			previousBlank = blank
+		}
+		if !opts.TrimTrailing {
+			end = len(row)
+		}
+
+		if written > 0 {
+			// Errors are sticky in bufio.Writer, checking one write for each row is enough
+			if err := bw.WriteByte('\n'); err != nil {
+				return cw.n, err
+			}
+		}
+		written++
+		for _, r := range row[:end] {
+			if r == 0 {
+				r = transparent
+			}
+			bw.WriteRune(r)
+		}
+	}
+	if written > 0 && !opts.NoFinalNewline {
+		bw.WriteByte('\n')
+	}
+	err = bw.Flush()
+	return cw.n, err
+}
+
+// blankSuffix returns the index at which the transparent cells and spaces at the end of row begin.
+func blankSuffix(row []rune) int {
+	end := len(row)
+	for end > 0 && (row[end-1] == 0 || row[end-1] == ' ') {
+		end--
+	}
+	return end
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write satisfies the io.Writer interface.
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,69 @@
+package drawer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSprint(t *testing.T) {
+	d, err := NewDrawer(4, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Row 0 ends with an explicit space, rows 1 and 2 are blank, row 3 has a space in the middle
+	d.DrawRune('a', 0, 0)
+	d.DrawRune(' ', 1, 0)
+	d.DrawRune(' ', 0, 2)
+	d.DrawRune('b', 1, 3)
+	d.DrawRune(' ', 2, 3)
+	d.DrawRune('c', 3, 3)
+
+	tests := []struct {
+		opts     OutputOptions
+		expected []string
+	}{
+		{OutputOptions{}, []string{"a   ", "    ", "    ", " b c", "    ", ""}},
+		{OutputOptions{Transparent: '·'}, []string{"a ··", "····", " ···", "·b c", "····", ""}},
+		{OutputOptions{TrimTrailing: true}, []string{"a", "", "", " b c", "", ""}},
+		{OutputOptions{TrimTrailing: true, Transparent: '·'}, []string{"a", "", "", "·b c", "", ""}},
+		{OutputOptions{CollapseBlankRows: true}, []string{"a   ", "    ", " b c", "    ", ""}},
+		{OutputOptions{NoFinalNewline: true}, []string{"a   ", "    ", "    ", " b c", "    "}},
+		{OutputOptions{TrimTrailing: true, CollapseBlankRows: true, NoFinalNewline: true}, []string{"a", "", " b c", ""}},
+	}
+	for _, test := range tests {
+		expected := strings.Join(test.expected, "\n")
+		if s := d.Sprint(test.opts); s != expected {
+			t.Errorf("%+v: expected %q, received %q", test.opts, expected, s)
+		}
+		var b strings.Builder
+		n, err := d.Fprint(&b, test.opts)
+		if err != nil || b.String() != expected || n != int64(len(expected)) {
+			t.Errorf("%+v: Fprint should write what Sprint returns, received %q of %d bytes with error %v", test.opts, b.String(), n, err)
+		}
+	}
+}
+
+func TestFprintTrailingBlankRows(t *testing.T) {
+	d, err := NewDrawer(1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Rows 1 and 2 are blank and collapse into a single row, which is the last one written
+	d.DrawRune('a', 0, 0)
+
+	tests := []struct {
+		opts     OutputOptions
+		expected string
+	}{
+		{OutputOptions{CollapseBlankRows: true}, "a\n \n"},
+		{OutputOptions{CollapseBlankRows: true, NoFinalNewline: true}, "a\n "},
+		{OutputOptions{TrimTrailing: true, CollapseBlankRows: true, NoFinalNewline: true}, "a\n"},
+	}
+	for _, test := range tests {
+		var b strings.Builder
+		n, err := d.Fprint(&b, test.opts)
+		if err != nil || b.String() != test.expected || n != int64(len(test.expected)) {
+			t.Errorf("%+v: expected %q, received %q of %d bytes with error %v", test.opts, test.expected, b.String(), n, err)
+		}
+	}
+}
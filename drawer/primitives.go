@@ -0,0 +1,289 @@
+package drawer
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Align describes how a line of text is placed with respect to its reference column.
+type Align int
+
+const (
+	// AlignLeft starts the text at the reference column.
+	AlignLeft Align = iota
+	// AlignCenter centres the text on the reference column,
+	// when the text has an even number of runes the extra one goes to the right.
+	AlignCenter
+	// AlignRight ends the text at the reference column.
+	AlignRight
+)
+
+// cell is a rune to draw in position x, y.
+type cell struct {
+	x, y int
+	r    rune
+	// open is the direction of the arm of r which is dropped when merging r with a box-drawing rune,
+	// since it leaves the end of a line, or -1 if r is merged as a whole
+	open int
+}
+
+// drawCells draws cells onto the canvas, combined with the runes already there according to mode.
+// If clip is true the cells outside the canvas are skipped,
+// otherwise a growing drawer grows to include all the cells and any other drawer
+// returns an error, without drawing anything, if any cell is outside the canvas.
+func (d *Drawer) drawCells(cells []cell, mode Mode, clip bool) error {
+	if !clip {
+		if d.growing && len(cells) > 0 {
+			minX, minY, maxX, maxY := cells[0].x, cells[0].y, cells[0].x, cells[0].y
+			for _, c := range cells {
+				if c.x < minX {
+					minX = c.x
+				}
+				if c.x > maxX {
+					maxX = c.x
+				}
+				if c.y < minY {
+					minY = c.y
+				}
+				if c.y > maxY {
+					maxY = c.y
+				}
+			}
+			d.grow(Rect{X: minX, Y: minY, W: maxX - minX + 1, H: maxY - minY + 1})
+		}
+		for _, c := range cells {
+			if !d.inside(c.x, c.y) {
+				return d.outsideError(c.x, c.y)
+			}
+		}
+	}
+	for _, c := range cells {
+		if !d.inside(c.x, c.y) {
+			continue
+		}
+		below := d.cell(c.x, c.y)
+		if mode == MergeLines && c.r != 0 && c.open >= 0 {
+			*below = mergeEnd(*below, c.r, c.open)
+			continue
+		}
+		*below = mode.combine(*below, c.r)
+	}
+	return nil
+}
+
+// textCells returns the cells of s, with each line drawn on its own row starting from row y
+// and placed with respect to column x according to align.
+func textCells(s string, x, y int, align Align) ([]cell, error) {
+	if align != AlignLeft && align != AlignCenter && align != AlignRight {
+		return nil, fmt.Errorf("unknown alignment %d", align)
+	}
+	var cells []cell
+	for i, line := range strings.Split(s, "\n") {
+		start := x
+		switch n := utf8.RuneCountInString(line); align {
+		case AlignCenter:
+			start = x - (n-1)/2
+		case AlignRight:
+			start = x - n + 1
+		}
+		// The position is incremented manually since ranging over a string increments it by bytes
+		lineX := start
+		for _, r := range line {
+			cells = append(cells, cell{x: lineX, y: y + i, r: r, open: -1})
+			lineX++
+		}
+	}
+	return cells, nil
+}
+
+// lineCells returns the cells of a line of length runes r starting from position x, y
+// and going in direction, which is right or down.
+func lineCells(x, y, length, direction int, r rune) ([]cell, error) {
+	if length < 0 {
+		return nil, fmt.Errorf("length must be non-negative, received %d", length)
+	}
+	dx, dy, start, end := 1, 0, left, right
+	if direction == down {
+		dx, dy, start, end = 0, 1, up, down
+	}
+	cells := make([]cell, length)
+	for i := range cells {
+		cells[i] = cell{x: x + i*dx, y: y + i*dy, r: r, open: -1}
+	}
+	if length > 0 {
+		cells[0].open = start
+		cells[length-1].open = end
+	}
+	if length == 1 {
+		// A line of a single rune joins what is below it on both sides
+		cells[0].open = -1
+	}
+	return cells, nil
+}
+
+// boxCells returns the cells of the border of rect drawn with the runes of style,
+// the zero Style means RoundedStyle.
+func boxCells(rect Rect, style Style) ([]cell, error) {
+	if rect.W < 2 || rect.H < 2 {
+		return nil, fmt.Errorf("a box must be at least 2 by 2, received %d by %d", rect.W, rect.H)
+	}
+	if style == (Style{}) {
+		style = RoundedStyle
+	}
+	endX, endY := rect.X+rect.W-1, rect.Y+rect.H-1
+	cells := []cell{
+		{rect.X, rect.Y, style.TopLeft, -1}, {endX, rect.Y, style.TopRight, -1},
+		{rect.X, endY, style.BottomLeft, -1}, {endX, endY, style.BottomRight, -1},
+	}
+	for x := rect.X + 1; x < endX; x++ {
+		cells = append(cells, cell{x, rect.Y, style.Horizontal, -1}, cell{x, endY, style.Horizontal, -1})
+	}
+	for y := rect.Y + 1; y < endY; y++ {
+		cells = append(cells, cell{rect.X, y, style.Vertical, -1}, cell{endX, y, style.Vertical, -1})
+	}
+	return cells, nil
+}
+
+// intersect returns the rectangle covered by both a and b, which is empty if they don't overlap.
+func intersect(a, b Rect) Rect {
+	startX, startY := a.X, a.Y
+	if b.X > startX {
+		startX = b.X
+	}
+	if b.Y > startY {
+		startY = b.Y
+	}
+	endX, endY := a.X+a.W, a.Y+a.H
+	if b.X+b.W < endX {
+		endX = b.X + b.W
+	}
+	if b.Y+b.H < endY {
+		endY = b.Y + b.H
+	}
+	if startX >= endX || startY >= endY {
+		return Rect{X: startX, Y: startY}
+	}
+	return Rect{X: startX, Y: startY, W: endX - startX, H: endY - startY}
+}
+
+// fillCells returns the cells of rect, each holding r.
+func fillCells(rect Rect, r rune) ([]cell, error) {
+	if rect.W < 0 || rect.H < 0 {
+		return nil, fmt.Errorf("width and height must be non-negative, received %d %d", rect.W, rect.H)
+	}
+	cells := make([]cell, 0, rect.W*rect.H)
+	for y := rect.Y; y < rect.Y+rect.H; y++ {
+		for x := rect.X; x < rect.X+rect.W; x++ {
+			cells = append(cells, cell{x, y, r, -1})
+		}
+	}
+	return cells, nil
+}
+
+// DrawText draws s with each line on its own row starting from row y,
+// placed with respect to column x according to align.
+// Spaces in s are drawn, so they overwrite the runes below them.
+// Returns an error, without drawing anything, if the text overflows the canvas or if align is unknown.
+func (d *Drawer) DrawText(s string, x, y int, align Align) error {
+	cells, err := textCells(s, x, y, align)
+	if err != nil {
+		return err
+	}
+	return d.drawCells(cells, Overwrite, false)
+}
+
+// DrawTextClipped draws s like DrawText, skipping the runes outside the canvas.
+func (d *Drawer) DrawTextClipped(s string, x, y int, align Align) {
+	cells, _ := textCells(s, x, y, align)
+	d.drawCells(cells, Overwrite, true)
+}
+
+// HLine draws a horizontal line of length runes r, starting from position x, y and going right.
+// Box-drawing runes are merged with the ones below them, like in MergeLines mode,
+// so that the line joins the lines it crosses.
+// Returns an error, without drawing anything, if the line overflows the canvas or if length is negative.
+func (d *Drawer) HLine(x, y, length int, r rune) error {
+	cells, err := lineCells(x, y, length, right, r)
+	if err != nil {
+		return err
+	}
+	return d.drawCells(cells, MergeLines, false)
+}
+
+// HLineClipped draws a line like HLine, skipping the runes outside the canvas.
+func (d *Drawer) HLineClipped(x, y, length int, r rune) {
+	cells, _ := lineCells(x, y, length, right, r)
+	d.drawCells(cells, MergeLines, true)
+}
+
+// VLine draws a vertical line of length runes r, starting from position x, y and going down.
+// Box-drawing runes are merged with the ones below them, like in MergeLines mode,
+// so that the line joins the lines it crosses.
+// Returns an error, without drawing anything, if the line overflows the canvas or if length is negative.
+func (d *Drawer) VLine(x, y, length int, r rune) error {
+	cells, err := lineCells(x, y, length, down, r)
+	if err != nil {
+		return err
+	}
+	return d.drawCells(cells, MergeLines, false)
+}
+
+// VLineClipped draws a line like VLine, skipping the runes outside the canvas.
+func (d *Drawer) VLineClipped(x, y, length int, r rune) {
+	cells, _ := lineCells(x, y, length, down, r)
+	d.drawCells(cells, MergeLines, true)
+}
+
+// Box draws the border of rect with the runes of style, the zero Style means RoundedStyle.
+// The border is merged with the lines below it, like in MergeLines mode,
+// so that boxes sharing an edge get joined.
+// Returns an error, without drawing anything, if the box overflows the canvas or if it is smaller than 2 by 2.
+func (d *Drawer) Box(rect Rect, style Style) error {
+	cells, err := boxCells(rect, style)
+	if err != nil {
+		return err
+	}
+	return d.drawCells(cells, MergeLines, false)
+}
+
+// BoxClipped draws a box like Box, skipping the runes outside the canvas.
+func (d *Drawer) BoxClipped(rect Rect, style Style) {
+	cells, _ := boxCells(rect, style)
+	d.drawCells(cells, MergeLines, true)
+}
+
+// Fill overwrites every cell of rect with r, filling it with 0 makes the cells transparent again.
+// Returns an error, without drawing anything, if rect overflows the canvas or if its dimensions are negative.
+func (d *Drawer) Fill(rect Rect, r rune) error {
+	cells, err := fillCells(rect, r)
+	if err != nil {
+		return err
+	}
+	return d.drawCells(cells, Overwrite, false)
+}
+
+// FillClipped fills rect like Fill, skipping the cells outside the canvas.
+func (d *Drawer) FillClipped(rect Rect, r rune) {
+	// Restricting rect to the canvas in advance, since it can be much larger
+	rect = intersect(rect, d.Bounds())
+	cells, _ := fillCells(rect, r)
+	d.drawCells(cells, Overwrite, true)
+}
+
+// DrawDrawerClipped draws the canvas inside e onto d like DrawDrawerMode,
+// skipping the runes which fall outside d instead of returning an error.
+// Like the other clipped variants, it never makes a growing drawer grow.
+func (d *Drawer) DrawDrawerClipped(e *Drawer, x, y int, mode Mode) {
+	if !mode.valid() {
+		return
+	}
+	for i, row := range e.canvas {
+		for j, r := range row {
+			if d.inside(j+x, i+y) {
+				c := d.cell(j+x, i+y)
+				*c = mode.combine(*c, r)
+			}
+		}
+	}
+}
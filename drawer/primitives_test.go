@@ -0,0 +1,136 @@
+package drawer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDrawText(t *testing.T) {
+	d, err := NewDrawer(7, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, align := range []Align{AlignLeft, AlignCenter, AlignRight} {
+		err = d.DrawText("ab", 3, i, align)
+		if err != nil {
+			t.Errorf("alignment %d: unexpected error: %v", align, err)
+		}
+	}
+	expected := "   ab  \n   ab  \n  ab   \n"
+	if d.String() != expected {
+		t.Errorf("expected %q, received %q", expected, d.String())
+	}
+
+	d, _ = NewDrawer(3, 2)
+	if err := d.DrawText("abc\nd", 1, 0, AlignCenter); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if d.String() != "abc\n d \n" {
+		t.Errorf("each line should be aligned on its own, received %q", d.String())
+	}
+	if err := d.DrawText("xy", 2, 1, AlignLeft); err == nil {
+		t.Errorf("expected error for overflowing text, received nil")
+	}
+	if d.String() != "abc\n d \n" {
+		t.Errorf("nothing should be drawn when the text overflows, received %q", d.String())
+	}
+	if err := d.DrawText("x", 0, 0, Align(5)); err == nil {
+		t.Errorf("expected error for unknown alignment, received nil")
+	}
+	d.DrawTextClipped("xy", 2, 1, AlignLeft)
+	if d.String() != "abc\n dx\n" {
+		t.Errorf("the text should be clipped, received %q", d.String())
+	}
+}
+
+func TestLinesAndBoxes(t *testing.T) {
+	d, err := NewDrawer(5, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Box(Rect{X: 0, Y: 0, W: 5, H: 5}, Style{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.HLine(0, 2, 5, '─'); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.VLine(2, 0, 5, '│'); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := strings.Join([]string{
+		"╭─┬─╮",
+		"│ │ │",
+		"├─┼─┤",
+		"│ │ │",
+		"╰─┴─╯",
+		"",
+	}, "\n")
+	if d.String() != expected {
+		t.Errorf("expected:\n%s\nreceived:\n%s", expected, d.String())
+	}
+
+	if err := d.HLine(3, 0, 3, '━'); err == nil {
+		t.Errorf("expected error for overflowing line, received nil")
+	}
+	if err := d.VLine(0, 0, -1, '│'); err == nil {
+		t.Errorf("expected error for negative length, received nil")
+	}
+	if err := d.Box(Rect{X: 0, Y: 0, W: 1, H: 3}, Style{}); err == nil {
+		t.Errorf("expected error for a box thinner than 2, received nil")
+	}
+	if err := d.Box(Rect{X: 2, Y: 2, W: 4, H: 2}, SquareStyle); err == nil {
+		t.Errorf("expected error for overflowing box, received nil")
+	}
+	if d.String() != expected {
+		t.Errorf("nothing should be drawn after errors, received:\n%s", d.String())
+	}
+
+	// The heavy line starts on the light one and continues beyond the canvas,
+	// only the right edge of the box is inside the canvas
+	d.HLineClipped(3, 0, 3, '━')
+	d.BoxClipped(Rect{X: -2, Y: 3, W: 3, H: 4}, Style{})
+	expected = strings.Join([]string{
+		"╭─┬╼┯",
+		"│ │ │",
+		"├─┼─┤",
+		"┤ │ │",
+		"├─┴─╯",
+		"",
+	}, "\n")
+	if d.String() != expected {
+		t.Errorf("expected:\n%s\nreceived:\n%s", expected, d.String())
+	}
+}
+
+func TestFill(t *testing.T) {
+	d, err := NewDrawer(3, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Fill(Rect{X: 0, Y: 0, W: 3, H: 2}, '*'); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Fill(Rect{X: 1, Y: 0, W: 1, H: 2}, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r, _ := d.Rune(1, 1); r != 0 {
+		t.Errorf("filling with 0 should make cells transparent, received %q", r)
+	}
+	if err := d.Fill(Rect{X: 2, Y: 0, W: 2, H: 1}, '#'); err == nil {
+		t.Errorf("expected error for overflowing rectangle, received nil")
+	}
+	d.FillClipped(Rect{X: 2, Y: -1000, W: 1000, H: 1001}, '#')
+	d.FillClipped(Rect{X: 5, Y: 5, W: 2, H: 2}, '#')
+	if expected := "* #\n* *\n"; d.String() != expected {
+		t.Errorf("expected %q, received %q", expected, d.String())
+	}
+
+	e, _ := NewDrawer(2, 2)
+	e.DrawRune('─', 0, 0)
+	e.DrawRune('a', 1, 1)
+	d.DrawDrawerClipped(e, 2, 0, SkipTransparent)
+	d.DrawDrawerClipped(e, -1, 0, SkipTransparent)
+	if expected := "* ─\na *\n"; d.String() != expected {
+		t.Errorf("expected %q, received %q", expected, d.String())
+	}
+}
@@ -0,0 +1,72 @@
+package drawer
+
+// Style is the set of runes used to draw boxes and the lines connecting them.
+type Style struct {
+	// Horizontal and Vertical are used for straight lines.
+	Horizontal, Vertical rune
+	// TopLeft, TopRight, BottomLeft and BottomRight are used for corners.
+	TopLeft, TopRight, BottomLeft, BottomRight rune
+	// TeeDown, TeeUp, TeeRight and TeeLeft are used where a line joins another line,
+	// they are named after the direction of the joining line, like ┬ for TeeDown.
+	TeeDown, TeeUp, TeeRight, TeeLeft rune
+	// Cross is used where two lines cross each other.
+	Cross rune
+}
+
+// Predefined styles.
+var (
+	// RoundedStyle uses light lines and rounded corners, it is the default style of trees.
+	RoundedStyle = Style{'─', '│', '╭', '╮', '╰', '╯', '┬', '┴', '├', '┤', '┼'}
+	// SquareStyle uses light lines and square corners.
+	SquareStyle = Style{'─', '│', '┌', '┐', '└', '┘', '┬', '┴', '├', '┤', '┼'}
+	// HeavyStyle uses heavy lines.
+	HeavyStyle = Style{'━', '┃', '┏', '┓', '┗', '┛', '┳', '┻', '┣', '┫', '╋'}
+	// DoubleStyle uses double lines.
+	DoubleStyle = Style{'═', '║', '╔', '╗', '╚', '╝', '╦', '╩', '╠', '╣', '╬'}
+	// ASCIIStyle uses only ASCII characters, for terminals without unicode support.
+	ASCIIStyle = Style{'-', '|', '+', '+', '+', '+', '+', '+', '+', '+', '+'}
+)
+
+// Emphasize returns r with its arms in the directions set to true drawn with the weight of the lines of s,
+// like '┸' for '┴' emphasized upwards with HeavyStyle: arms which r doesn't have are not added.
+// Where no rune mixes the weights, like for some double and light junctions, every arm of r gets the weight of s.
+// r is returned unchanged if r or the lines of s are not box-drawing runes, the zero Style means RoundedStyle.
+func (s Style) Emphasize(r rune, up, right, down, left bool) rune {
+	if s == (Style{}) {
+		s = RoundedStyle
+	}
+	a, ok := lineArms[r]
+	weight := s.weight()
+	if !ok || weight == ' ' {
+		return r
+	}
+	emphasized := a
+	for direction, emphasize := range [4]bool{up, right, down, left} {
+		if emphasize && a[direction] != ' ' {
+			emphasized[direction] = weight
+		}
+	}
+	if emphasized == a {
+		return r
+	}
+	if e, ok := armsLine[emphasized]; ok {
+		return e
+	}
+	for direction := range a {
+		if a[direction] != ' ' {
+			a[direction] = weight
+		}
+	}
+	if e, ok := armsLine[a]; ok {
+		return e
+	}
+	return r
+}
+
+// weight returns the weight of the horizontal lines of s, as in arms, or ' ' if they are not box-drawing runes.
+func (s Style) weight() byte {
+	if a, ok := lineArms[s.Horizontal]; ok {
+		return a[right]
+	}
+	return ' '
+}
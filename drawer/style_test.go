@@ -0,0 +1,31 @@
+package drawer
+
+import "testing"
+
+func TestEmphasize(t *testing.T) {
+	tests := []struct {
+		style                 Style
+		r                     rune
+		up, right, down, left bool
+		expected              rune
+	}{
+		{HeavyStyle, '┴', true, false, false, false, '┸'},
+		{HeavyStyle, '┴', true, true, false, false, '┺'},
+		{HeavyStyle, '┼', false, true, false, true, '┿'},
+		{HeavyStyle, '┼', true, false, false, false, '╀'},
+		{HeavyStyle, '╭', true, true, true, true, '┏'},
+		{HeavyStyle, '─', true, false, true, false, '─'},
+		{HeavyStyle, 'a', true, true, true, true, 'a'},
+		{DoubleStyle, '┬', false, false, true, false, '╥'},
+		// There is no rune with double up and right arms and a light left arm
+		{DoubleStyle, '┴', true, true, false, false, '╩'},
+		{ASCIIStyle, '┴', true, true, true, true, '┴'},
+		{Style{}, '┸', true, false, false, false, '┴'},
+	}
+	for _, test := range tests {
+		if r := test.style.Emphasize(test.r, test.up, test.right, test.down, test.left); r != test.expected {
+			t.Errorf("%c emphasized %v %v %v %v with %c: expected %c, received %c",
+				test.r, test.up, test.right, test.down, test.left, test.style.Horizontal, test.expected, r)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package drawer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rect describes a rectangle of cells with the up left corner in position X, Y,
+// width W and height H.
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Viewport returns a new drawer with a copy of the cells of d inside rect.
+// The cells of rect outside the canvas of d are left empty.
+// Returns an error if rect has negative width or height.
+func (d *Drawer) Viewport(rect Rect) (*Drawer, error) {
+	v, err := NewDrawer(rect.W, rect.H)
+	if err != nil {
+		return nil, fmt.Errorf("error while allocating viewport: %v", err)
+	}
+	for y := 0; y < rect.H; y++ {
+		for x := 0; x < rect.W; x++ {
+			if d.inside(x+rect.X, y+rect.Y) {
+				v.canvas[y][x] = *d.cell(x+rect.X, y+rect.Y)
+			}
+		}
+	}
+	return v, nil
+}
+
+// Tile is one of the pieces in which Tiles splits a canvas.
+type Tile struct {
+	*Drawer
+	// Number is the number of the tile, starting from 1 and counting row by row.
+	Number int
+	// Total is the number of tiles in which the canvas has been split.
+	Total int
+	// Rect is the portion of the original canvas covered by the tile.
+	Rect Rect
+	// Overlap is the number of columns and rows shared with the tiles on the left and above.
+	Overlap int
+	// Left, Right, Above and Below are the numbers of the neighbouring tiles, 0 if there is none.
+	Left, Right, Above, Below int
+}
+
+// Tiles splits the canvas into tiles at most w columns wide and h rows high, numbered row by row.
+// Consecutive tiles share overlap columns or rows so that the content around the edges can be followed.
+// Returns an error if w or h are not positive or if overlap is negative or doesn't leave room for new content.
+func (d *Drawer) Tiles(w, h, overlap int) ([]*Tile, error) {
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("width and height of tiles must be positive, received %d %d", w, h)
+	}
+	if overlap < 0 || overlap >= w || overlap >= h {
+		return nil, fmt.Errorf("overlap must be non-negative and smaller than width and height of tiles, received %d", overlap)
+	}
+
+	dW, dH := d.Dimens()
+	cols, rows := tilesCount(dW, w, overlap), tilesCount(dH, h, overlap)
+	tiles := make([]*Tile, 0, cols*rows)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			rect := Rect{X: col * (w - overlap), Y: row * (h - overlap), W: w, H: h}
+			// Clipping the tiles on the right and bottom edges
+			if rect.X+rect.W > dW {
+				rect.W = dW - rect.X
+			}
+			if rect.Y+rect.H > dH {
+				rect.H = dH - rect.Y
+			}
+			// Tiles are numbered from the up left corner of the canvas, which is not 0, 0 for drawers grown to negative positions
+			v, err := d.Viewport(Rect{X: rect.X - d.originX, Y: rect.Y - d.originY, W: rect.W, H: rect.H})
+			if err != nil {
+				return nil, fmt.Errorf("error while extracting tile %d %d: %v", col, row, err)
+			}
+
+			tile := &Tile{Drawer: v, Number: row*cols + col + 1, Total: cols * rows, Rect: rect, Overlap: overlap}
+			if col > 0 {
+				tile.Left = tile.Number - 1
+			}
+			if col < cols-1 {
+				tile.Right = tile.Number + 1
+			}
+			if row > 0 {
+				tile.Above = tile.Number - cols
+			}
+			if row < rows-1 {
+				tile.Below = tile.Number + cols
+			}
+			tiles = append(tiles, tile)
+		}
+	}
+	return tiles, nil
+}
+
+// tilesCount returns the number of tiles of size n, sharing overlap cells, needed to cover size cells.
+func tilesCount(size, n, overlap int) int {
+	if size <= n {
+		return 1
+	}
+	step := n - overlap
+	return 1 + (size-n+step-1)/step
+}
+
+// String returns the string representation of the tile, with a header describing which portion
+// of the original canvas it covers and which tiles it overlaps,
+// and a footer with the tiles in which the content continues.
+func (t *Tile) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "tile %d of %d: rows %d-%d, columns %d-%d\n", t.Number, t.Total, t.Rect.Y, t.Rect.Y+t.Rect.H-1, t.Rect.X, t.Rect.X+t.Rect.W-1)
+	if t.Overlap > 0 && t.Left != 0 {
+		fmt.Fprintf(&b, "← first %d columns repeated from tile %d\n", t.Overlap, t.Left)
+	}
+	if t.Overlap > 0 && t.Above != 0 {
+		fmt.Fprintf(&b, "↑ first %d rows repeated from tile %d\n", t.Overlap, t.Above)
+	}
+	b.WriteString(t.Drawer.String())
+	if t.Right != 0 {
+		fmt.Fprintf(&b, "→ continued on tile %d\n", t.Right)
+	}
+	if t.Below != 0 {
+		fmt.Fprintf(&b, "↓ continued on tile %d\n", t.Below)
+	}
+	return b.String()
+}
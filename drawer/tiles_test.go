@@ -0,0 +1,87 @@
+package drawer
+
+import (
+	"fmt"
+	"testing"
+)
+
+// alphabetDrawer returns a drawer with width w and height h filled row by row with the letters of the alphabet.
+func alphabetDrawer(w, h int) *Drawer {
+	d, _ := NewDrawer(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			d.DrawRune(rune('a'+(y*w+x)%26), x, y)
+		}
+	}
+	return d
+}
+
+func TestViewport(t *testing.T) {
+	d := alphabetDrawer(4, 3)
+
+	v, err := d.Viewport(Rect{X: 1, Y: 1, W: 2, H: 2})
+	if err != nil {
+		t.Errorf("you should be able to extract a viewport inside the canvas: %v", err)
+	}
+	if s := v.String(); s != "fg\njk\n" {
+		t.Errorf("expected viewport fg jk, received %q", s)
+	}
+
+	v, err = d.Viewport(Rect{X: -1, Y: 2, W: 3, H: 2})
+	if err != nil {
+		t.Errorf("you should be able to extract a viewport partially outside the canvas: %v", err)
+	}
+	if s := v.String(); s != " ij\n   \n" {
+		t.Errorf("cells outside the canvas should be empty, received %q", s)
+	}
+
+	_, err = d.Viewport(Rect{W: -1, H: 1})
+	if err == nil {
+		t.Errorf("you shouldn't be able to extract a viewport with negative width")
+	}
+}
+
+func TestTiles(t *testing.T) {
+	d := alphabetDrawer(10, 5)
+
+	tiles, err := d.Tiles(4, 3, 1)
+	if err != nil {
+		t.Errorf("you should be able to split the canvas into tiles: %v", err)
+	}
+	// Columns start at 0, 3, 6 and rows at 0, 2
+	if len(tiles) != 6 {
+		t.Fatalf("expected 6 tiles, received %d", len(tiles))
+	}
+
+	second := tiles[1]
+	if second.Rect != (Rect{X: 3, Y: 0, W: 4, H: 3}) {
+		t.Errorf("unexpected rect of the second tile %v", second.Rect)
+	}
+	if second.Left != 1 || second.Right != 3 || second.Above != 0 || second.Below != 5 {
+		t.Errorf("unexpected neighbours of the second tile %d %d %d %d", second.Left, second.Right, second.Above, second.Below)
+	}
+	if s := second.Drawer.String(); s != "defg\nnopq\nxyza\n" {
+		t.Errorf("unexpected content of the second tile %q", s)
+	}
+
+	last := tiles[5]
+	if last.Rect != (Rect{X: 6, Y: 2, W: 4, H: 3}) {
+		t.Errorf("unexpected rect of the last tile %v", last.Rect)
+	}
+	if last.Right != 0 || last.Below != 0 {
+		t.Errorf("the last tile shouldn't have neighbours on the right or below")
+	}
+
+	for _, tile := range tiles {
+		fmt.Println(tile)
+	}
+
+	_, err = d.Tiles(0, 3, 0)
+	if err == nil {
+		t.Errorf("you shouldn't be able to split the canvas into tiles with zero width")
+	}
+	_, err = d.Tiles(4, 3, 3)
+	if err == nil {
+		t.Errorf("you shouldn't be able to split the canvas into tiles with overlap equal to their height")
+	}
+}
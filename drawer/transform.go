@@ -0,0 +1,130 @@
+package drawer
+
+// asciiArms maps the ASCII runes for lines to their arms, like lineArms does for box-drawing runes.
+var asciiArms = map[rune]arms{'-': {' ', 'L', ' ', 'L'}, '|': {'L', ' ', 'L', ' '}}
+
+// arrowArms maps each arrow to an arm pointing in its direction.
+var arrowArms = map[rune]arms{
+	'↑': {'L', ' ', ' ', ' '}, '→': {' ', 'L', ' ', ' '}, '↓': {' ', ' ', 'L', ' '}, '←': {' ', ' ', ' ', 'L'},
+}
+
+// roundedArms maps the rounded corners to their arms, they are kept apart to map them to rounded corners.
+var roundedArms = map[rune]arms{
+	'╭': {' ', 'L', 'L', ' '}, '╮': {' ', ' ', 'L', 'L'}, '╯': {'L', ' ', ' ', 'L'}, '╰': {'L', 'L', ' ', ' '},
+}
+
+// diagonals maps each diagonal line to the other one of the same kind.
+var diagonals = map[rune]rune{'/': '\\', '\\': '/', '╱': '╲', '╲': '╱'}
+
+// armFamilies are the groups of runes which remap maps into each other by moving their arms,
+// rounded corners come first so that they are mapped to rounded corners.
+var armFamilies = []struct {
+	arms  map[rune]arms
+	runes map[arms]rune
+}{
+	{roundedArms, invertArms(roundedArms)},
+	{lineArms, armsLine},
+	{asciiArms, invertArms(asciiArms)},
+	{arrowArms, invertArms(arrowArms)},
+}
+
+// invertArms returns the map from arms to runes of m.
+func invertArms(m map[rune]arms) map[arms]rune {
+	inverse := make(map[arms]rune, len(m))
+	for r, a := range m {
+		inverse[a] = r
+	}
+	return inverse
+}
+
+// remap returns the rune which looks like r after moving its arms with move,
+// like '┤' for '┬' rotated clockwise. Runes without arms are returned unchanged.
+func remap(r rune, move func(arms) arms) rune {
+	for _, family := range armFamilies {
+		if a, ok := family.arms[r]; ok {
+			if moved, ok := family.runes[move(a)]; ok {
+				return moved
+			}
+			return r
+		}
+	}
+
+	if other, ok := diagonals[r]; ok {
+		// Following where the upper right end of a rising diagonal goes,
+		// if rising diagonals stay rising, falling ones stay falling too
+		end := move(arms{'L', 'L', ' ', ' '})
+		if (end[up] != ' ' && end[right] != ' ') || (end[down] != ' ' && end[left] != ' ') {
+			return r
+		}
+		return other
+	}
+	return r
+}
+
+// transform returns a new drawer w by h in which each rune of d in position x, y
+// is moved to position(x, y) and remapped with move.
+func (d *Drawer) transform(w, h int, position func(x, y int) (int, int), move func(arms) arms) *Drawer {
+	t, _ := NewDrawer(w, h)
+	for y, row := range d.canvas {
+		for x, r := range row {
+			tX, tY := position(x, y)
+			t.canvas[tY][tX] = remap(r, move)
+		}
+	}
+	return t
+}
+
+// FlipHorizontal returns a new drawer with the columns of d in reverse order,
+// with each rune replaced by its mirror image where there is one, like '╭' which becomes '╮'.
+func (d *Drawer) FlipHorizontal() *Drawer {
+	w, h := d.Dimens()
+	return d.transform(w, h, func(x, y int) (int, int) {
+		return w - 1 - x, y
+	}, func(a arms) arms {
+		return arms{a[up], a[left], a[down], a[right]}
+	})
+}
+
+// FlipVertical returns a new drawer with the rows of d in reverse order,
+// with each rune replaced by its mirror image where there is one, like '╭' which becomes '╰'.
+func (d *Drawer) FlipVertical() *Drawer {
+	w, h := d.Dimens()
+	return d.transform(w, h, func(x, y int) (int, int) {
+		return x, h - 1 - y
+	}, func(a arms) arms {
+		return arms{a[down], a[right], a[up], a[left]}
+	})
+}
+
+// Transpose returns a new drawer in which the rows of d become columns,
+// with each rune replaced by its reflection along the diagonal where there is one, like '┬' which becomes '├'.
+func (d *Drawer) Transpose() *Drawer {
+	w, h := d.Dimens()
+	return d.transform(h, w, func(x, y int) (int, int) {
+		return y, x
+	}, func(a arms) arms {
+		return arms{a[left], a[down], a[right], a[up]}
+	})
+}
+
+// RotateClockwise returns a new drawer with d rotated by 90 degrees clockwise,
+// with each rune replaced by its rotated version where there is one, like '┬' which becomes '┤'.
+func (d *Drawer) RotateClockwise() *Drawer {
+	w, h := d.Dimens()
+	return d.transform(h, w, func(x, y int) (int, int) {
+		return h - 1 - y, x
+	}, func(a arms) arms {
+		return arms{a[left], a[up], a[right], a[down]}
+	})
+}
+
+// RotateCounterclockwise returns a new drawer with d rotated by 90 degrees counterclockwise,
+// with each rune replaced by its rotated version where there is one, like '┬' which becomes '├'.
+func (d *Drawer) RotateCounterclockwise() *Drawer {
+	w, h := d.Dimens()
+	return d.transform(h, w, func(x, y int) (int, int) {
+		return y, w - 1 - x
+	}, func(a arms) arms {
+		return arms{a[right], a[down], a[left], a[up]}
+	})
+}
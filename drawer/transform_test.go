@@ -0,0 +1,93 @@
+package drawer
+
+import (
+	"strings"
+	"testing"
+)
+
+// transformTestDrawer returns a drawer with a small box connected to a line below it and a label.
+func transformTestDrawer(t *testing.T) *Drawer {
+	d, err := NewDrawer(4, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.Box(Rect{X: 0, Y: 0, W: 3, H: 3}, Style{})
+	d.DrawRune('┬', 1, 2)
+	d.DrawRune('│', 1, 3)
+	d.DrawRune('/', 3, 0)
+	d.DrawRune('→', 3, 1)
+	d.DrawRune('a', 3, 3)
+	return d
+}
+
+func TestTransforms(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(*Drawer) *Drawer
+		expected  []string
+	}{
+		{"flip horizontal", (*Drawer).FlipHorizontal, []string{
+			"\\╭─╮",
+			"←│ │",
+			" ╰┬╯",
+			"a │ ",
+		}},
+		{"flip vertical", (*Drawer).FlipVertical, []string{
+			" │ a",
+			"╭┴╮ ",
+			"│ │→",
+			"╰─╯\\",
+		}},
+		{"transpose", (*Drawer).Transpose, []string{
+			"╭─╮ ",
+			"│ ├─",
+			"╰─╯ ",
+			"/↓ a",
+		}},
+		{"rotate clockwise", (*Drawer).RotateClockwise, []string{
+			" ╭─╮",
+			"─┤ │",
+			" ╰─╯",
+			"a ↓\\",
+		}},
+		{"rotate counterclockwise", (*Drawer).RotateCounterclockwise, []string{
+			"\\↑ a",
+			"╭─╮ ",
+			"│ ├─",
+			"╰─╯ ",
+		}},
+	}
+	for _, test := range tests {
+		expected := strings.Join(test.expected, "\n") + "\n"
+		if s := test.transform(transformTestDrawer(t)).String(); s != expected {
+			t.Errorf("%s: expected:\n%s\nreceived:\n%s", test.name, expected, s)
+		}
+	}
+}
+
+func TestTransformsInverse(t *testing.T) {
+	d, err := NewDrawer(5, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.DrawText("┏━┳╗-", 0, 0, AlignLeft)
+	d.DrawText("╠═╬┫|", 0, 1, AlignLeft)
+	d.DrawText("╙┘╲← ", 0, 2, AlignLeft)
+	original := d.String()
+
+	for name, s := range map[string]string{
+		"flip horizontal twice": d.FlipHorizontal().FlipHorizontal().String(),
+		"flip vertical twice":   d.FlipVertical().FlipVertical().String(),
+		"transpose twice":       d.Transpose().Transpose().String(),
+		"rotate back":           d.RotateClockwise().RotateCounterclockwise().String(),
+		"rotate four times":     d.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise().String(),
+		"flips and rotations":   d.FlipHorizontal().FlipVertical().RotateClockwise().RotateClockwise().String(),
+	} {
+		if s != original {
+			t.Errorf("%s: expected:\n%s\nreceived:\n%s", name, original, s)
+		}
+	}
+	if w, h := d.Transpose().Dimens(); w != 3 || h != 5 {
+		t.Errorf("transposing should swap the dimensions, received %d by %d", w, h)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/m1gwings/treedrawer
 
-go 1.14
+go 1.18
@@ -0,0 +1,94 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/m1gwings/treedrawer/drawer"
+)
+
+// ParentAlign describes where a node is drawn with respect to its children.
+type ParentAlign int
+
+const (
+	// ParentCenter centres nodes above all of their children, it is the default alignment.
+	ParentCenter ParentAlign = iota
+	// ParentFirst draws nodes straight above their first child, with the other children on its right,
+	// like a left-aligned list.
+	ParentFirst
+	// ParentMiddle draws nodes straight above their middle child,
+	// the left one of the two in the middle when the children are even.
+	ParentMiddle
+)
+
+// valid reports whether a is one of the defined parent alignments.
+func (a ParentAlign) valid() bool {
+	return a >= ParentCenter && a <= ParentMiddle
+}
+
+// validTextAlign reports whether a is one of the alignments defined by drawer.
+func validTextAlign(a drawer.Align) bool {
+	return a == drawer.AlignLeft || a == drawer.AlignCenter || a == drawer.AlignRight
+}
+
+// SetParentAlign sets where t is drawn with respect to its children, overriding RenderOptions.ParentAlign.
+// Returns an error if a is not a ParentAlign.
+func (t *Tree) SetParentAlign(a ParentAlign) error {
+	if !a.valid() {
+		return fmt.Errorf("unknown parent alignment %d", a)
+	}
+	t.parentAlign = &a
+	return nil
+}
+
+// SetTextAlign sets how the lines of the value of t are aligned inside its box, overriding RenderOptions.TextAlign.
+// Only NodeString and NodeSections values are aligned, the others are drawn as they are.
+// Returns an error if a is not a drawer.Align.
+func (t *Tree) SetTextAlign(a drawer.Align) error {
+	if !validTextAlign(a) {
+		return fmt.Errorf("unknown text alignment %d", a)
+	}
+	t.textAlign = &a
+	return nil
+}
+
+// ResetAlign removes the alignments set on t, which goes back to the ones of RenderOptions.
+func (t *Tree) ResetAlign() {
+	t.parentAlign, t.textAlign = nil, nil
+}
+
+// parentAlign returns where t is drawn with respect to its children.
+func (r *renderer) parentAlign(t *Tree) ParentAlign {
+	if t.parentAlign != nil {
+		return *t.parentAlign
+	}
+	return r.opts.ParentAlign
+}
+
+// textAlign returns how the lines of the value of t are aligned inside its box.
+func (r *renderer) textAlign(t *Tree) drawer.Align {
+	if t.textAlign != nil {
+		return *t.textAlign
+	}
+	return r.opts.TextAlign
+}
+
+// alignText draws the lines of s aligned according to align in a drawer as wide as the longest line.
+func alignText(s string, align drawer.Align) *drawer.Drawer {
+	d := NodeString(s).Draw()
+	if align == drawer.AlignLeft {
+		return d
+	}
+	w, h := d.Dimens()
+	d, _ = drawer.NewDrawer(w, h)
+	for y, line := range strings.Split(s, "\n") {
+		// Centred lines leave the extra column on the right, like children centred below their parent
+		x := w - utf8.RuneCountInString(line)
+		if align == drawer.AlignCenter {
+			x /= 2
+		}
+		d.DrawText(line, x, y, drawer.AlignLeft)
+	}
+	return d
+}
@@ -0,0 +1,233 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/m1gwings/treedrawer/drawer"
+)
+
+// alignTree returns a tree with a node with two children and two leaves below the root.
+func alignTree(t *testing.T) *Tree {
+	tr, err := FromBrackets(strings.NewReader("{root{a{a1}{a2}}{b}{c}}"))
+	if err != nil {
+		t.Fatalf("the brackets should describe a tree: %v", err)
+	}
+	return tr
+}
+
+func TestRenderParentAlign(t *testing.T) {
+	tests := []struct {
+		align    ParentAlign
+		expected []string
+	}{
+		{ParentFirst, []string{
+			"╭────╮              ",
+			"│root│              ",
+			"╰──┬─╯              ",
+			"   ├──────────┬───╮ ",
+			"  ╭┴╮        ╭┴╮ ╭┴╮",
+			"  │a│        │b│ │c│",
+			"  ╰┬╯        ╰─╯ ╰─╯",
+			"   ├─────╮          ",
+			" ╭─┴╮  ╭─┴╮         ",
+			" │a1│  │a2│         ",
+			" ╰──╯  ╰──╯         ",
+		}},
+		{ParentMiddle, []string{
+			"          ╭────╮   ",
+			"          │root│   ",
+			"          ╰──┬─╯   ",
+			"  ╭──────────┼───╮ ",
+			" ╭┴╮        ╭┴╮ ╭┴╮",
+			" │a│        │b│ │c│",
+			" ╰┬╯        ╰─╯ ╰─╯",
+			"  ├─────╮          ",
+			"╭─┴╮  ╭─┴╮         ",
+			"│a1│  │a2│         ",
+			"╰──╯  ╰──╯         ",
+		}},
+	}
+	tr := alignTree(t)
+	for _, test := range tests {
+		s, err := tr.Render(RenderOptions{ParentAlign: test.align})
+		if err != nil {
+			t.Errorf("rendering with parent alignment %d should succeed: %v", test.align, err)
+			continue
+		}
+		if expected := strings.Join(test.expected, "\n") + "\n"; s != expected {
+			t.Errorf("rendering with parent alignment %d, expected\n%s\ngot\n%s", test.align, expected, s)
+		}
+	}
+
+	// ParentCenter is the default
+	s, err := tr.Render(RenderOptions{ParentAlign: ParentCenter})
+	if err != nil {
+		t.Fatalf("rendering with ParentCenter should succeed: %v", err)
+	}
+	if s != tr.String() {
+		t.Errorf("ParentCenter should draw the tree like String, expected\n%s\ngot\n%s", tr, s)
+	}
+}
+
+func TestRenderTextAlign(t *testing.T) {
+	tr := NewTree(NodeString("a\nlonger\nlines"))
+	tests := []struct {
+		align    drawer.Align
+		expected []string
+	}{
+		{drawer.AlignCenter, []string{
+			"╭──────╮ ",
+			"│  a   │ ",
+			"│longer│ ",
+			"│lines │ ",
+			"╰──────╯ ",
+		}},
+		{drawer.AlignRight, []string{
+			"╭──────╮ ",
+			"│     a│ ",
+			"│longer│ ",
+			"│ lines│ ",
+			"╰──────╯ ",
+		}},
+	}
+	for _, test := range tests {
+		s, err := tr.Render(RenderOptions{TextAlign: test.align})
+		if err != nil {
+			t.Errorf("rendering with text alignment %d should succeed: %v", test.align, err)
+			continue
+		}
+		if expected := strings.Join(test.expected, "\n") + "\n"; s != expected {
+			t.Errorf("rendering with text alignment %d, expected\n%s\ngot\n%s", test.align, expected, s)
+		}
+	}
+}
+
+func TestRenderTextAlignCenter(t *testing.T) {
+	// Lines with an odd and an even number of runes, in a box with an odd and then an even width
+	tests := []struct {
+		val      string
+		expected []string
+	}{
+		{"ab\nc\nlongest\nodd", []string{
+			"╭───────╮",
+			"│  ab   │",
+			"│   c   │",
+			"│longest│",
+			"│  odd  │",
+			"╰───────╯",
+		}},
+		{"ab\nc\nlonger\nodd", []string{
+			"╭──────╮ ",
+			"│  ab  │ ",
+			"│  c   │ ",
+			"│longer│ ",
+			"│ odd  │ ",
+			"╰──────╯ ",
+		}},
+	}
+	for _, test := range tests {
+		s, err := NewTree(NodeString(test.val)).Render(RenderOptions{TextAlign: drawer.AlignCenter})
+		if err != nil {
+			t.Fatalf("rendering with centred text should succeed: %v", err)
+		}
+		if expected := strings.Join(test.expected, "\n") + "\n"; s != expected {
+			t.Errorf("rendering %q with centred text, expected\n%s\ngot\n%s", test.val, expected, s)
+		}
+	}
+}
+
+func TestSetAlign(t *testing.T) {
+	tr := alignTree(t)
+	tr.SetParentAlign(ParentMiddle)
+	tr.Children()[0].SetParentAlign(ParentFirst)
+	c := tr.Children()[2]
+	c.SetVal(NodeString("c\nlast"))
+	c.SetTextAlign(drawer.AlignRight)
+	expected := strings.Join([]string{
+		"          ╭────╮       ",
+		"          │root│       ",
+		"          ╰──┬─╯       ",
+		"  ╭──────────┼─────╮   ",
+		" ╭┴╮        ╭┴╮ ╭──┴─╮ ",
+		" │a│        │b│ │   c│ ",
+		" ╰┬╯        ╰─╯ │last│ ",
+		"  ├─────╮       ╰────╯ ",
+		"╭─┴╮  ╭─┴╮             ",
+		"│a1│  │a2│             ",
+		"╰──╯  ╰──╯             ",
+	}, "\n") + "\n"
+	if s := tr.String(); s != expected {
+		t.Errorf("the alignments of nodes should override the options, expected\n%s\ngot\n%s", expected, s)
+	}
+	if clone := tr.Clone(); clone.String() != expected {
+		t.Errorf("Clone should keep the alignments of nodes, expected\n%s\ngot\n%s", expected, clone)
+	}
+
+	tr.Walk(func(n *Tree) error {
+		n.ResetAlign()
+		return nil
+	})
+	s, err := tr.Render(RenderOptions{ParentAlign: ParentMiddle, TextAlign: drawer.AlignRight})
+	if err != nil {
+		t.Fatalf("rendering should succeed: %v", err)
+	}
+	if !strings.Contains(s, "│   c│") || !strings.Contains(s, "╭──────────┼───") {
+		t.Errorf("after ResetAlign the options should apply, got\n%s", s)
+	}
+
+	if err := tr.SetParentAlign(ParentAlign(42)); err == nil {
+		t.Errorf("an unknown parent alignment should be rejected")
+	}
+	if err := tr.SetTextAlign(drawer.Align(42)); err == nil {
+		t.Errorf("an unknown text alignment should be rejected")
+	}
+	for _, opts := range []RenderOptions{{ParentAlign: ParentAlign(42)}, {TextAlign: drawer.Align(42)}} {
+		if _, err := tr.Render(opts); err == nil {
+			t.Errorf("rendering with %+v should fail", opts)
+		}
+	}
+}
+
+func TestParseDrawingParentAlign(t *testing.T) {
+	tr := alignTree(t)
+	for _, align := range []ParentAlign{ParentFirst, ParentMiddle} {
+		s, err := tr.Render(RenderOptions{ParentAlign: align})
+		if err != nil {
+			t.Fatalf("rendering should succeed: %v", err)
+		}
+		parsed, err := ParseDrawing(s)
+		if err != nil {
+			t.Errorf("the drawing with parent alignment %d should be parsed: %v", align, err)
+			continue
+		}
+		if parsed.String() != tr.String() {
+			t.Errorf("the drawing with parent alignment %d should be read back as the same tree, got\n%s", align, parsed)
+		}
+	}
+}
+
+func TestHighlightParentAlign(t *testing.T) {
+	tr := alignTree(t)
+	a1 := tr.Children()[0].Children()[0]
+	s, err := tr.Render(RenderOptions{ParentAlign: ParentFirst, Highlight: HighlightNodes(a1)})
+	if err != nil {
+		t.Fatalf("rendering should succeed: %v", err)
+	}
+	expected := strings.Join([]string{
+		"╭────╮              ",
+		"│root│              ",
+		"╰──┰─╯              ",
+		"   ┠──────────┬───╮ ",
+		"  ╭┸╮        ╭┴╮ ╭┴╮",
+		"  │a│        │b│ │c│",
+		"  ╰┰╯        ╰─╯ ╰─╯",
+		"   ┠─────╮          ",
+		" ┏━┻┓  ╭─┴╮         ",
+		" ┃a1┃  │a2│         ",
+		" ┗━━┛  ╰──╯         ",
+	}, "\n") + "\n"
+	if s != expected {
+		t.Errorf("the path straight down to the first child should be highlighted, expected\n%s\ngot\n%s", expected, s)
+	}
+}
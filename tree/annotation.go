@@ -0,0 +1,77 @@
+package tree
+
+import (
+	"unicode/utf8"
+
+	"github.com/m1gwings/treedrawer/drawer"
+)
+
+// AnnotationPosition describes where the annotations of nodes are drawn.
+type AnnotationPosition int
+
+const (
+	// AnnotationInBorder draws annotations in the top border of boxes, like ╭─ 3 ─╮,
+	// boxes get wider when needed so that annotations don't cover the connection to the parent.
+	AnnotationInBorder AnnotationPosition = iota
+	// AnnotationRight draws annotations to the right of boxes,
+	// leaving as many free columns on the left so that boxes stay centered above their children.
+	AnnotationRight
+)
+
+// annotatedVal returns the drawer of the value of t, given that t is depth edges below the root,
+// with the margin to leave on both sides of its box to make room for the annotation of t.
+// In orientations which turn rows into columns, the annotation follows the value inside the box,
+// since it would be drawn vertically anywhere else.
+func (r *renderer) annotatedVal(t *Tree, depth int) boxed {
+	val := r.boxVal(t.val, r.textAlign(t))
+	if t.annotation == "" {
+		return val
+	}
+	dVal := val.d
+	n := utf8.RuneCountInString(t.annotation)
+	dValW, dValH := dVal.Dimens()
+
+	if r.opts.Orientation.transposed() {
+		d, _ := drawer.NewDrawer(dValW+1+n, dValH)
+		d.DrawDrawer(dVal, 0, 0)
+		d.DrawText(t.annotation, dValW+1, 0, drawer.AlignLeft)
+		val.d = d
+		return val
+	}
+	if r.opts.AnnotationPosition == AnnotationRight {
+		val.margin = n + 1
+		return val
+	}
+
+	// The annotation, with a space on both sides, goes at the end of the top border before a ─,
+	// below the root it must stay right of the ┴ of the parent, which is in the middle of the border
+	minW := n + 4
+	if depth > 0 {
+		minW = 2*n + 7
+	}
+	if dValW >= minW {
+		return val
+	}
+	d, _ := drawer.NewDrawer(minW, dValH)
+	d.DrawDrawer(dVal, (minW-dValW)/2, 0)
+	val.d = d
+	return val
+}
+
+// annotate draws the annotation of t onto d, the drawer of t composed with p.
+func (r *renderer) annotate(d *drawer.Drawer, t *Tree, p placement) {
+	if t.annotation == "" || r.opts.Orientation.transposed() {
+		return
+	}
+	// The annotation is unoriented like values, so that it ends up in its original orientation
+	dAnnotation := r.opts.Orientation.unorient(NodeString(t.annotation).Draw())
+	n, _ := dAnnotation.Dimens()
+
+	x, y := p.box.X+p.box.W+1, p.box.Y+p.box.H/2
+	if r.opts.AnnotationPosition == AnnotationInBorder {
+		x, y = p.box.X+p.box.W-n-3, p.box.Y
+		d.DrawRune(' ', x-1, y)
+		d.DrawRune(' ', x+n, y)
+	}
+	d.DrawDrawer(dAnnotation, x, y)
+}
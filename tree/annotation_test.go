@@ -0,0 +1,105 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// annotatedTree returns a tree in which the nodes with children are annotated with the size of their subtree.
+func annotatedTree(t *testing.T) *Tree {
+	tr, err := FromBrackets(strings.NewReader("{root{a{a1}{a2}}{b{b1}}{c}}"))
+	if err != nil {
+		t.Fatalf("the brackets should describe a tree: %v", err)
+	}
+	tr.Walk(func(n *Tree) error {
+		if len(n.Children()) > 0 {
+			n.SetAnnotation(fmt.Sprint(n.Size()))
+		}
+		return nil
+	})
+	tr.Find(func(n *Tree) bool { return Label(n.Val()) == "c" }).SetAnnotation("✓")
+	return tr
+}
+
+func TestRenderAnnotations(t *testing.T) {
+	tr := annotatedTree(t)
+	tests := []struct {
+		opts     RenderOptions
+		expected []string
+	}{
+		{RenderOptions{}, []string{
+			"              ╭─ 7 ─╮              ",
+			"              │root │              ",
+			"              ╰──┬──╯              ",
+			"     ╭───────────┼───────────╮     ",
+			"╭────┴ 3 ─╮ ╭────┴ 2 ─╮ ╭────┴ ✓ ─╮",
+			"│    a    │ │    b    │ │    c    │",
+			"╰────┬────╯ ╰────┬────╯ ╰─────────╯",
+			"  ╭──┴──╮        │                 ",
+			"╭─┴╮  ╭─┴╮     ╭─┴╮                ",
+			"│a1│  │a2│     │b1│                ",
+			"╰──╯  ╰──╯     ╰──╯                ",
+		}},
+		{RenderOptions{AnnotationPosition: AnnotationRight}, []string{
+			"          ╭────╮           ",
+			"          │root│ 7         ",
+			"          ╰──┬─╯           ",
+			"     ╭───────┴─┬───────╮   ",
+			"    ╭┴╮       ╭┴╮     ╭┴╮  ",
+			"    │a│ 3     │b│ 2   │c│ ✓",
+			"    ╰┬╯       ╰┬╯     ╰─╯  ",
+			"  ╭──┴──╮      │           ",
+			"╭─┴╮  ╭─┴╮   ╭─┴╮          ",
+			"│a1│  │a2│   │b1│          ",
+			"╰──╯  ╰──╯   ╰──╯          ",
+		}},
+	}
+	for _, test := range tests {
+		s, err := tr.Render(test.opts)
+		if err != nil {
+			t.Errorf("the options should be valid: %v", err)
+			continue
+		}
+		expected := strings.Join(test.expected, "\n") + "\n"
+		if s != expected {
+			t.Errorf("expected\n%s\nreceived\n%s", expected, s)
+		}
+	}
+
+	// Sideways annotations follow the value inside the box
+	s, err := tr.Render(RenderOptions{Orientation: LeftRight})
+	if err != nil {
+		t.Errorf("the options should be valid: %v", err)
+	}
+	if !strings.Contains(s, "┤a 3├") || !strings.Contains(s, "│root 7├") {
+		t.Errorf("expected the annotations inside the boxes, received\n%s", s)
+	}
+
+	_, err = tr.Render(RenderOptions{AnnotationPosition: AnnotationPosition(42)})
+	if err == nil {
+		t.Errorf("unknown annotation positions shouldn't be accepted")
+	}
+}
+
+func TestAnnotation(t *testing.T) {
+	tr := annotatedTree(t)
+	if a := tr.Annotation(); a != "7" {
+		t.Errorf("expected annotation 7, received %q", a)
+	}
+	if a := tr.Clone().Annotation(); a != "7" {
+		t.Errorf("expected clones to keep annotations, received %q", a)
+	}
+	tr.SetAnnotation("two\nlines")
+	if a := tr.Annotation(); a != "two lines" {
+		t.Errorf("expected newlines to be replaced, received %q", a)
+	}
+
+	s, err := annotatedTree(t).Outline(RenderOptions{})
+	if err != nil {
+		t.Fatalf("the outline should be drawn: %v", err)
+	}
+	if !strings.HasPrefix(s, "root ─ 7\n├── a ─ 3\n") {
+		t.Errorf("expected annotations after the labels, received\n%s", s)
+	}
+}
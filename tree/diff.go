@@ -0,0 +1,339 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/m1gwings/treedrawer/drawer"
+)
+
+// Change describes what happened to a node between the old and the new version of a tree.
+type Change int
+
+const (
+	// Unchanged nodes are in both trees with equal values.
+	Unchanged Change = iota
+	// Inserted nodes are only in the new tree.
+	Inserted
+	// Deleted nodes are only in the old tree.
+	Deleted
+	// Moved nodes are in both trees with equal values, but under another parent or in another order among their siblings.
+	Moved
+	// Relabelled nodes are in the same place in both trees, with different values.
+	Relabelled
+)
+
+// markers are drawn before the label of changed nodes.
+var markers = map[Change]string{Inserted: "+", Deleted: "-", Moved: ">", Relabelled: "~"}
+
+// colors are the ANSI escape codes of the colors used by ColorDiff for the boxes of changed nodes.
+var colors = map[Change]string{Inserted: "\x1b[32m", Deleted: "\x1b[31m", Moved: "\x1b[36m", Relabelled: "\x1b[33m"}
+
+// DiffNode is the value of the nodes of the tree returned by Diff.
+type DiffNode struct {
+	Change Change
+	// Old is the value of the node in the old tree, it is nil for inserted nodes.
+	Old NodeValue
+	// New is the value of the node in the new tree, it is nil for deleted nodes.
+	New NodeValue
+}
+
+// Draw satisfies the NodeValue interface.
+// The label of the node is preceded by the marker of its change: + for inserted, - for deleted,
+// > for moved and ~ for relabelled nodes, which show both labels as "~ old → new" when they fit on a line.
+func (n DiffNode) Draw() *drawer.Drawer {
+	var label string
+	switch {
+	case n.New == nil:
+		label = Label(n.Old)
+	case n.Change == Relabelled && !strings.Contains(Label(n.Old)+Label(n.New), "\n"):
+		label = Label(n.Old) + " → " + Label(n.New)
+	default:
+		label = Label(n.New)
+	}
+	if marker, ok := markers[n.Change]; ok {
+		// Indenting the following lines under the first one
+		label = marker + " " + strings.ReplaceAll(label, "\n", "\n  ")
+	}
+	return NodeString(label).Draw()
+}
+
+// differ holds the nodes of the old and of the new tree matched by Diff.
+type differ struct {
+	eq func(x, y NodeValue) bool
+	// toNew and toOld map the matched nodes of the old tree to the new one and vice versa
+	toNew, toOld map[*Tree]*Tree
+	// changes holds the change of the matched nodes of the new tree which are moved or relabelled
+	changes map[*Tree]Change
+}
+
+// Diff compares the old tree rooted at a with the new tree rooted at b
+// and returns a tree which merges them, where each node holds a DiffNode describing its change.
+// eq reports whether two values are equal, if it is nil values are equal when their labels are equal.
+//
+// The roots are always matched, then the children of matched nodes with equal values are matched
+// keeping their order, like lines in a text diff.
+// The nodes left are matched by value anywhere in the trees and reported as moved,
+// then the nodes left between the same matched siblings are matched in order and reported as relabelled.
+// Nodes matched to no other node are reported as inserted or deleted.
+//
+// The merged tree has the structure of b, with deleted nodes placed after their closest preceding sibling
+// in a, and moved nodes drawn only in their new place.
+func Diff(a, b *Tree, eq func(x, y NodeValue) bool) *Tree {
+	if eq == nil {
+		eq = func(x, y NodeValue) bool { return Label(x) == Label(y) }
+	}
+	df := &differ{eq: eq, toNew: make(map[*Tree]*Tree), toOld: make(map[*Tree]*Tree), changes: make(map[*Tree]Change)}
+
+	change := Unchanged
+	if !eq(a.val, b.val) {
+		change = Relabelled
+	}
+	df.match(a, b, change)
+	df.descend(a, b)
+
+	// Matching the values left anywhere in the trees, as moved nodes
+	var old []*Tree
+	a.Walk(func(n *Tree) error {
+		old = append(old, n)
+		return nil
+	})
+	b.Walk(func(n *Tree) error {
+		if _, ok := df.toOld[n]; ok {
+			return nil
+		}
+		for _, m := range old {
+			if _, ok := df.toNew[m]; !ok && eq(m.val, n.val) {
+				df.match(m, n, Moved)
+				df.descend(m, n)
+				break
+			}
+		}
+		return nil
+	})
+
+	df.relabel(a, b)
+	return df.merge(b)
+}
+
+// match matches the node x of the old tree with the node y of the new one.
+func (df *differ) match(x, y *Tree, change Change) {
+	df.toNew[x], df.toOld[y] = y, x
+	if change != Unchanged {
+		df.changes[y] = change
+	}
+}
+
+// unmatched returns the nodes in nodes which are not in matched.
+func unmatched(nodes []*Tree, matched map[*Tree]*Tree) []*Tree {
+	var left []*Tree
+	for _, n := range nodes {
+		if _, ok := matched[n]; !ok {
+			left = append(left, n)
+		}
+	}
+	return left
+}
+
+// descend matches the longest sequence of children of x and y with equal values, in the same order,
+// and descends in each matched pair.
+// This function is called recursively
+func (df *differ) descend(x, y *Tree) {
+	xs, ys := unmatched(x.children, df.toNew), unmatched(y.children, df.toOld)
+	// lcs[i][j] is the length of the longest common sequence of xs[i:] and ys[j:]
+	lcs := make([][]int, len(xs)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(ys)+1)
+	}
+	for i := len(xs) - 1; i >= 0; i-- {
+		for j := len(ys) - 1; j >= 0; j-- {
+			switch {
+			case df.eq(xs[i].val, ys[j].val):
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	for i, j := 0, 0; i < len(xs) && j < len(ys); {
+		switch {
+		case df.eq(xs[i].val, ys[j].val):
+			df.match(xs[i], ys[j], Unchanged)
+			df.descend(xs[i], ys[j])
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+}
+
+// relabel matches in order the children of x and y which are left between the same pair of matched children,
+// as relabelled nodes, and goes on with every matched child of y.
+// This function is called recursively
+func (df *differ) relabel(x, y *Tree) {
+	// gaps holds the unmatched children of x and y between two children matched in order
+	type gap struct{ xs, ys []*Tree }
+	gaps := []gap{{}}
+	j := 0
+	for _, xChild := range x.children {
+		yChild, ok := df.toNew[xChild]
+		if !ok {
+			gaps[len(gaps)-1].xs = append(gaps[len(gaps)-1].xs, xChild)
+			continue
+		}
+		if yChild.parent != y || df.changes[yChild] == Moved || yChild.index() < j {
+			continue
+		}
+		// Closing the gap with the children of y up to yChild
+		for ; y.children[j] != yChild; j++ {
+			if _, ok := df.toOld[y.children[j]]; !ok {
+				gaps[len(gaps)-1].ys = append(gaps[len(gaps)-1].ys, y.children[j])
+			}
+		}
+		j++
+		gaps = append(gaps, gap{})
+	}
+	for ; j < len(y.children); j++ {
+		if _, ok := df.toOld[y.children[j]]; !ok {
+			gaps[len(gaps)-1].ys = append(gaps[len(gaps)-1].ys, y.children[j])
+		}
+	}
+
+	for _, g := range gaps {
+		for i := 0; i < len(g.xs) && i < len(g.ys); i++ {
+			df.match(g.xs[i], g.ys[i], Relabelled)
+			df.descend(g.xs[i], g.ys[i])
+		}
+	}
+	for _, yChild := range y.children {
+		if xChild, ok := df.toOld[yChild]; ok {
+			df.relabel(xChild, yChild)
+		}
+	}
+}
+
+// merge returns the merged tree rooted at the node y of the new tree.
+// This function is called recursively
+func (df *differ) merge(y *Tree) *Tree {
+	x, ok := df.toOld[y]
+	if !ok {
+		m := NewTree(DiffNode{Change: Inserted, New: y.val})
+		for _, yChild := range y.children {
+			m.attach(df.merge(yChild))
+		}
+		return m
+	}
+	m := NewTree(DiffNode{Change: df.changes[y], Old: x.val, New: y.val})
+
+	// deleted holds the deleted children of x after the index of the child of y matched to their closest preceding sibling,
+	// or after -1 when there is no such sibling
+	deleted := make(map[int][]*Tree)
+	last := -1
+	for _, xChild := range x.children {
+		yChild, ok := df.toNew[xChild]
+		if !ok {
+			deleted[last] = append(deleted[last], df.deleted(xChild))
+			continue
+		}
+		if yChild.parent == y {
+			last = yChild.index()
+		}
+	}
+
+	for _, d := range deleted[-1] {
+		m.attach(d)
+	}
+	for i, yChild := range y.children {
+		m.attach(df.merge(yChild))
+		for _, d := range deleted[i] {
+			m.attach(d)
+		}
+	}
+	return m
+}
+
+// deleted returns the merged tree rooted at the deleted node x of the old tree,
+// its descendants which have been moved are left out.
+// This function is called recursively
+func (df *differ) deleted(x *Tree) *Tree {
+	m := NewTree(DiffNode{Change: Deleted, Old: x.val})
+	for _, xChild := range x.children {
+		if _, ok := df.toNew[xChild]; !ok {
+			m.attach(df.deleted(xChild))
+		}
+	}
+	return m
+}
+
+// attach adds child, which has no parent, as the last child of t.
+func (t *Tree) attach(child *Tree) {
+	child.parent = t
+	t.children = append(t.children, child)
+}
+
+// index returns the position of t among the children of its parent.
+func (t *Tree) index() int {
+	for i, sibling := range t.parent.children {
+		if sibling == t {
+			return i
+		}
+	}
+	return -1
+}
+
+// ColorDiff draws the tree returned by Diff like Canvas, with ANSI escape codes
+// which color the box of each changed node: green for inserted, red for deleted,
+// cyan for moved and yellow for relabelled nodes.
+// Markers are drawn anyway, so that changes are visible where colors are not.
+// opts.Output is ignored and the drawing is never split into pages.
+// Returns an error if opts are not valid.
+func ColorDiff(merged *Tree, opts RenderOptions) (string, error) {
+	d, boxes, err := merged.Layout(opts)
+	if err != nil {
+		return "", fmt.Errorf("error while drawing the diff: %v", err)
+	}
+	w, h := d.Dimens()
+	color := make([][]string, h)
+	for y := range color {
+		color[y] = make([]string, w)
+	}
+	for n, box := range boxes {
+		diffNode, ok := n.val.(DiffNode)
+		if !ok {
+			continue
+		}
+		for y := box.Y; y < box.Y+box.H; y++ {
+			for x := box.X; x < box.X+box.W; x++ {
+				color[y][x] = colors[diffNode.Change]
+			}
+		}
+	}
+
+	var b strings.Builder
+	for y := 0; y < h; y++ {
+		current := ""
+		for x := 0; x < w; x++ {
+			if color[y][x] != current {
+				if current != "" {
+					b.WriteString("\x1b[0m")
+				}
+				b.WriteString(color[y][x])
+				current = color[y][x]
+			}
+			r, _ := d.Rune(x, y)
+			if r == 0 {
+				r = ' '
+			}
+			b.WriteRune(r)
+		}
+		if current != "" {
+			b.WriteString("\x1b[0m")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
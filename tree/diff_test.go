@@ -0,0 +1,86 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+)
+
+// diffChanges returns the label and the marker of each node of the tree returned by Diff, in pre-order.
+func diffChanges(merged *Tree) (changes []string) {
+	merged.Walk(func(n *Tree) error {
+		changes = append(changes, Label(n.Val()))
+		return nil
+	})
+	return
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected []string
+	}{
+		{"{root{a}{b}}", "{root{a}{b}}", []string{"root", "a", "b"}},
+		{"{root{a}{b}}", "{new root{a}{b}{c}}", []string{"~ root → new root", "a", "b", "+ c"}},
+		{"{root{a{a1}}{b}}", "{root{b}}", []string{"root", "- a", "- a1", "b"}},
+		{"{root{a}{b}{c}}", "{root{a}{x}{c}}", []string{"root", "a", "~ b → x", "c"}},
+		{"{root{a}{b}}", "{root{b}{a}}", []string{"root", "b", "> a"}},
+		{"{root{a{m{m1}}}{b}}", "{root{a}{b{m{m1}}}}", []string{"root", "a", "b", "> m", "m1"}},
+		{"{root{a}{b}}", "{root{w{a}}{b}}", []string{"root", "+ w", "> a", "b"}},
+		{"{root{a}{b}{c}}", "{root{c}}", []string{"root", "- a", "- b", "c"}},
+	}
+	for _, test := range tests {
+		a, err := FromBrackets(strings.NewReader(test.a))
+		if err != nil {
+			t.Fatalf("%s should describe a tree: %v", test.a, err)
+		}
+		b, err := FromBrackets(strings.NewReader(test.b))
+		if err != nil {
+			t.Fatalf("%s should describe a tree: %v", test.b, err)
+		}
+		merged := Diff(a, b, nil)
+		checkParents(t, merged)
+		changes := diffChanges(merged)
+		if strings.Join(changes, "|") != strings.Join(test.expected, "|") {
+			t.Errorf("%s → %s: expected %q, received %q", test.a, test.b, test.expected, changes)
+		}
+	}
+}
+
+func TestDiffEq(t *testing.T) {
+	a := NewTree(NodeString("Root"))
+	a.AddChild(NodeInt64(1))
+	b := NewTree(NodeString("root"))
+	b.AddChild(NodeInt64(1))
+
+	eq := func(x, y NodeValue) bool { return strings.EqualFold(Label(x), Label(y)) }
+	merged := Diff(a, b, eq)
+	if n := merged.Val().(DiffNode); n.Change != Unchanged || n.Old != NodeString("Root") || n.New != NodeString("root") {
+		t.Errorf("expected the roots to be equal, received %+v", n)
+	}
+	child, _ := merged.Child(0)
+	if n := child.Val().(DiffNode); n.Change != Unchanged || n.Old != NodeInt64(1) {
+		t.Errorf("expected the children to be equal, received %+v", n)
+	}
+}
+
+func TestColorDiff(t *testing.T) {
+	a, _ := FromBrackets(strings.NewReader("{root{a}{b}}"))
+	b, _ := FromBrackets(strings.NewReader("{root{a}{c}}"))
+	s, err := ColorDiff(Diff(a, b, nil), RenderOptions{})
+	if err != nil {
+		t.Fatalf("the diff should be drawn: %v", err)
+	}
+	lines := strings.Split(s, "\n")
+	expected := "╭┴╮ " + colors[Relabelled] + "╭───┴───╮" + "\x1b[0m"
+	if lines[4] != expected {
+		t.Errorf("expected the box of the relabelled node to be colored\n%q\nreceived\n%q", expected, lines[4])
+	}
+	if strings.Contains(lines[0], "\x1b") {
+		t.Errorf("unchanged nodes shouldn't be colored, received %q", lines[0])
+	}
+
+	_, err = ColorDiff(Diff(a, b, nil), RenderOptions{MaxWidth: -1})
+	if err == nil {
+		t.Errorf("invalid options shouldn't be accepted")
+	}
+}
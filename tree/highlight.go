@@ -0,0 +1,104 @@
+package tree
+
+import "github.com/m1gwings/treedrawer/drawer"
+
+// HighlightNodes returns a function for RenderOptions.Highlight which highlights nodes.
+func HighlightNodes(nodes ...*Tree) func(*Tree) bool {
+	set := make(map[*Tree]bool, len(nodes))
+	for _, n := range nodes {
+		set[n] = true
+	}
+	return func(t *Tree) bool {
+		return set[t]
+	}
+}
+
+// highlight finds the nodes of the tree rooted at t which are highlighted according to opts.Highlight
+// and the nodes on the paths from t to them.
+func (r *renderer) highlight(t *Tree) {
+	r.highlighted, r.paths = nil, nil
+	if r.opts.Highlight == nil {
+		return
+	}
+	r.highlighted, r.paths = make(map[*Tree]bool), make(map[*Tree]bool)
+	t.Walk(func(n *Tree) error {
+		if !r.opts.Highlight(n) {
+			return nil
+		}
+		r.highlighted[n] = true
+		for current := n; current != nil && !r.paths[current]; current = current.parent {
+			r.paths[current] = true
+			if current == t {
+				break
+			}
+		}
+		return nil
+	})
+}
+
+// emphasize draws again with the weight of the highlight style the lines of d, the drawer of t composed with p,
+// which belong to the box of t, if it is highlighted, or to the connections on the path to a highlighted node.
+// outlined reports which children have been drawn in the outline layout, it is nil if none of them has.
+func (r *renderer) emphasize(d *drawer.Drawer, t *Tree, children []*Tree, p placement, outlined []bool) {
+	if r.paths == nil {
+		return
+	}
+	set := func(x, y int, up, right, down, left bool) {
+		current, err := d.Rune(x, y)
+		if err != nil {
+			return
+		}
+		d.DrawRune(r.highlightStyle.Emphasize(current, up, right, down, left), x, y)
+	}
+
+	if r.highlighted[t] {
+		box := p.box
+		endX, endY := box.X+box.W-1, box.Y+box.H-1
+		for _, corner := range [][2]int{{box.X, box.Y}, {endX, box.Y}, {box.X, endY}, {endX, endY}} {
+			set(corner[0], corner[1], true, true, true, true)
+		}
+		for x := box.X + 1; x < endX; x++ {
+			set(x, box.Y, false, true, false, true)
+			set(x, endY, false, true, false, true)
+		}
+		for y := box.Y + 1; y < endY; y++ {
+			set(box.X, y, true, false, true, false)
+			set(endX, y, true, false, true, false)
+		}
+	}
+
+	for i, tChild := range children {
+		onPath := r.paths[tChild]
+		top, x := p.children[i].Y, p.links[i]
+		// The ┴ above the child has been drawn over its box, which has to be highlighted again
+		boxed := r.highlighted[tChild] && (outlined == nil || !outlined[i])
+		set(x, top, onPath, boxed, false, boxed)
+		if !onPath {
+			continue
+		}
+
+		set(p.link, p.box.Y+p.box.H-1, false, false, true, false)
+		row := top - 1
+		// The pipe under the box is longer when the children have been moved down
+		for y := p.box.Y + p.box.H; y < row; y++ {
+			set(p.link, y, true, false, true, false)
+		}
+		if len(children) == 1 {
+			set(p.link, row, true, false, true, false)
+			continue
+		}
+		// Going along the line which joins the children, from the parent to the child
+		set(p.link, row, true, x > p.link, x == p.link, x < p.link)
+		if x == p.link {
+			continue
+		}
+		step := 1
+		if x < p.link {
+			step = -1
+		}
+		for between := p.link + step; between != x; between += step {
+			set(between, row, false, true, false, true)
+		}
+		set(x, row, false, x < p.link, true, x > p.link)
+	}
+}
@@ -0,0 +1,67 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/m1gwings/treedrawer/drawer"
+)
+
+func TestRenderHighlight(t *testing.T) {
+	tr, err := FromBrackets(strings.NewReader("{root{a{a1}{a2}}{b{b1}}{c}{d}}"))
+	if err != nil {
+		t.Fatalf("the brackets should describe a tree: %v", err)
+	}
+	byLabel := func(label string) *Tree {
+		return tr.Find(func(n *Tree) bool { return Label(n.Val()) == label })
+	}
+
+	tests := []struct {
+		opts     RenderOptions
+		expected []string
+	}{
+		{RenderOptions{Highlight: HighlightNodes(byLabel("a2"), byLabel("b1"))}, []string{
+			"         ╭────╮          ",
+			"         │root│          ",
+			"         ╰──┰─╯          ",
+			"     ┏━━━━━━┻━┱────┬───╮ ",
+			"    ╭┸╮      ╭┸╮  ╭┴╮ ╭┴╮",
+			"    │a│      │b│  │c│ │d│",
+			"    ╰┰╯      ╰┰╯  ╰─╯ ╰─╯",
+			"  ╭──┺━━┓     ┃          ",
+			"╭─┴╮  ┏━┻┓  ┏━┻┓         ",
+			"│a1│  ┃a2┃  ┃b1┃         ",
+			"╰──╯  ┗━━┛  ┗━━┛         ",
+		}},
+		{RenderOptions{Highlight: HighlightNodes(byLabel("c")), HighlightStyle: drawer.DoubleStyle}, []string{
+			"         ╭────╮          ",
+			"         │root│          ",
+			"         ╰──╥─╯          ",
+			"     ╭──────╩═╤════╦───╮ ",
+			"    ╭┴╮      ╭┴╮  ╔╩╗ ╭┴╮",
+			"    │a│      │b│  ║c║ │d│",
+			"    ╰┬╯      ╰┬╯  ╚═╝ ╰─╯",
+			"  ╭──┴──╮     │          ",
+			"╭─┴╮  ╭─┴╮  ╭─┴╮         ",
+			"│a1│  │a2│  │b1│         ",
+			"╰──╯  ╰──╯  ╰──╯         ",
+		}},
+	}
+	for _, test := range tests {
+		s, err := tr.Render(test.opts)
+		if err != nil {
+			t.Errorf("the options should be valid: %v", err)
+			continue
+		}
+		expected := strings.Join(test.expected, "\n") + "\n"
+		if s != expected {
+			t.Errorf("expected\n%s\nreceived\n%s", expected, s)
+		}
+	}
+
+	// Without highlighted nodes the drawing doesn't change
+	s, _ := tr.Render(RenderOptions{Highlight: func(*Tree) bool { return false }})
+	if s != tr.String() {
+		t.Errorf("expected\n%s\nreceived\n%s", tr.String(), s)
+	}
+}
@@ -0,0 +1,168 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// JSONOptions controls how FromJSON converts a JSON document into a tree.
+// The zero value keeps every string and every array element.
+type JSONOptions struct {
+	// MaxStringLength is the maximum number of runes of a JSON string drawn on a node,
+	// longer strings are truncated and terminated with "…".
+	// A value of 0 means no limit.
+	MaxStringLength int
+	// MaxArrayLength is the maximum number of elements of a JSON array drawn as children,
+	// the remaining elements are collapsed into a single "… N more" node.
+	// A value of 0 means no limit.
+	MaxArrayLength int
+}
+
+// FromJSON reads a JSON document from r and returns the tree describing its structure.
+// Objects become nodes with a child for each member, labelled with the key of the member,
+// arrays become nodes with a child for each element, labelled with the index of the element,
+// and scalars become leaves.
+// Returns an error if r does not contain exactly one valid JSON value.
+func FromJSON(r io.Reader, opts JSONOptions) (*Tree, error) {
+	if opts.MaxStringLength < 0 || opts.MaxArrayLength < 0 {
+		return nil, fmt.Errorf("options must be non-negative, received %d %d", opts.MaxStringLength, opts.MaxArrayLength)
+	}
+
+	dec := json.NewDecoder(r)
+	// Using json.Number to draw numbers exactly as they are written in the document
+	dec.UseNumber()
+
+	t := NewTree(NodeString(""))
+	err := parseJSONValue(dec, opts, t, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensuring that there is nothing after the first value
+	_, err = dec.Token()
+	if err != io.EOF {
+		if err == nil {
+			return nil, fmt.Errorf("unexpected data after the end of the JSON value at offset %d", dec.InputOffset())
+		}
+		return nil, fmt.Errorf("error while reading the end of the JSON value: %v", err)
+	}
+	return t, nil
+}
+
+// parseJSONValue reads the next JSON value from dec and stores it in t.
+// label is the key or the index which the value belongs to, it is empty for the root.
+// This function is called recursively
+func parseJSONValue(dec *json.Decoder, opts JSONOptions, t *Tree, label string) error {
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return fmt.Errorf("unexpected end of the JSON document")
+	}
+	if err != nil {
+		return fmt.Errorf("error while reading JSON token: %v", err)
+	}
+
+	delim, isDelim := tok.(json.Delim)
+	if !isDelim {
+		t.SetVal(NodeString(joinJSONLabel(label, formatJSONScalar(tok, opts))))
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		if !dec.More() {
+			t.SetVal(NodeString(joinJSONLabel(label, "{}")))
+			break
+		}
+		t.SetVal(NodeString(jsonContainerLabel(label, "{}")))
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return fmt.Errorf("error while reading JSON object key: %v", err)
+			}
+			key, ok := keyTok.(string)
+			if !ok {
+				return fmt.Errorf("expected a JSON object key, received %v", keyTok)
+			}
+			err = parseJSONValue(dec, opts, t.AddChild(NodeString("")), truncateString(key, opts.MaxStringLength))
+			if err != nil {
+				return err
+			}
+		}
+	case '[':
+		if !dec.More() {
+			t.SetVal(NodeString(joinJSONLabel(label, "[]")))
+			break
+		}
+		t.SetVal(NodeString(jsonContainerLabel(label, "[]")))
+		i := 0
+		for ; dec.More(); i++ {
+			if opts.MaxArrayLength > 0 && i >= opts.MaxArrayLength {
+				// Elements beyond the limit are read into a throwaway tree to keep the decoder in sync
+				err = parseJSONValue(dec, opts, NewTree(NodeString("")), "")
+			} else {
+				err = parseJSONValue(dec, opts, t.AddChild(NodeString("")), "["+strconv.Itoa(i)+"]")
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if opts.MaxArrayLength > 0 && i > opts.MaxArrayLength {
+			t.AddChild(NodeString(fmt.Sprintf("… %d more", i-opts.MaxArrayLength)))
+		}
+	default:
+		return fmt.Errorf("unexpected JSON delimiter %v", delim)
+	}
+
+	// Consuming the closing delimiter
+	_, err = dec.Token()
+	if err != nil {
+		return fmt.Errorf("error while reading the end of JSON %v: %v", delim, err)
+	}
+	return nil
+}
+
+// formatJSONScalar returns the string representation of a JSON scalar token.
+func formatJSONScalar(tok json.Token, opts JSONOptions) string {
+	switch v := tok.(type) {
+	case string:
+		return strconv.Quote(truncateString(v, opts.MaxStringLength))
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// joinJSONLabel returns the label of a leaf made by label and value.
+func joinJSONLabel(label, value string) string {
+	if label == "" {
+		return value
+	}
+	return label + ": " + value
+}
+
+// jsonContainerLabel returns the label of a non empty object or array,
+// brackets are used only for the root since other containers are labelled by their key or index.
+func jsonContainerLabel(label, brackets string) string {
+	if label == "" {
+		return brackets
+	}
+	return label
+}
+
+// truncateString returns s truncated to maxLength runes, terminated with "…" if truncated.
+// A maxLength of 0 means no limit.
+func truncateString(s string, maxLength int) string {
+	if maxLength <= 0 || utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:maxLength-1]) + "…"
+}
@@ -0,0 +1,69 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFromJSON(t *testing.T) {
+	doc := `{"name": "treedrawer", "stars": 42, "tags": ["go", "tree"], "license": null, "meta": {}}`
+	tr, err := FromJSON(strings.NewReader(doc), JSONOptions{})
+	if err != nil {
+		t.Fatalf("the document should be valid: %v", err)
+	}
+
+	if tr.Val() != NodeString("{}") {
+		t.Errorf("the root should be labelled {}, received %v", tr.Val())
+	}
+	expected := []NodeString{`name: "treedrawer"`, "stars: 42", "tags", "license: null", "meta: {}"}
+	if len(tr.Children()) != len(expected) {
+		t.Fatalf("the root should have %d children, received %d", len(expected), len(tr.Children()))
+	}
+	for i, e := range expected {
+		if v := tr.Children()[i].Val(); v != e {
+			t.Errorf("child %d should be labelled %s, received %v", i, e, v)
+		}
+	}
+
+	tags := tr.Children()[2]
+	if len(tags.Children()) != 2 || tags.Children()[1].Val() != NodeString(`[1]: "tree"`) {
+		t.Errorf("tags should have two children labelled with their index")
+	}
+
+	fmt.Println(tr)
+}
+
+func TestFromJSONOptions(t *testing.T) {
+	doc := `["a very long string", 1, 2, 3, [4, 5]]`
+	tr, err := FromJSON(strings.NewReader(doc), JSONOptions{MaxStringLength: 6, MaxArrayLength: 2})
+	if err != nil {
+		t.Fatalf("the document should be valid: %v", err)
+	}
+
+	expected := []NodeString{`[0]: "a ver…"`, "[1]: 1", "… 3 more"}
+	if len(tr.Children()) != len(expected) {
+		t.Fatalf("the root should have %d children, received %d", len(expected), len(tr.Children()))
+	}
+	for i, e := range expected {
+		if v := tr.Children()[i].Val(); v != e {
+			t.Errorf("child %d should be labelled %s, received %v", i, e, v)
+		}
+	}
+
+	fmt.Println(tr)
+}
+
+func TestFromJSONErrors(t *testing.T) {
+	for _, doc := range []string{``, `{"a": }`, `[1, 2`, `{} {}`, `{"a": 1]`} {
+		_, err := FromJSON(strings.NewReader(doc), JSONOptions{})
+		if err == nil {
+			t.Errorf("%q shouldn't be a valid document", doc)
+		}
+	}
+
+	_, err := FromJSON(strings.NewReader(`1`), JSONOptions{MaxArrayLength: -1})
+	if err == nil {
+		t.Errorf("negative options shouldn't be accepted")
+	}
+}
@@ -18,6 +18,15 @@ type NodeValue interface {
 	Draw() *drawer.Drawer
 }
 
+// Label returns the text drawn by n, with a line for each row of its drawer without trailing spaces.
+func Label(n NodeValue) string {
+	rows := strings.Split(strings.TrimSuffix(n.Draw().String(), "\n"), "\n")
+	for i, row := range rows {
+		rows[i] = strings.TrimRight(row, " ")
+	}
+	return strings.Join(rows, "\n")
+}
+
 // NodeInt64 is the default type for drawing int64s on the tree.
 type NodeInt64 int64
 
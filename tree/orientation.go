@@ -0,0 +1,83 @@
+package tree
+
+import "github.com/m1gwings/treedrawer/drawer"
+
+// Orientation describes where the root is drawn with respect to its descendants.
+type Orientation int
+
+const (
+	// TopDown draws the root at the top and children below their parent, it is the default orientation.
+	TopDown Orientation = iota
+	// BottomUp draws the root at the bottom and children above their parent.
+	BottomUp
+	// LeftRight draws the root on the left and children on the right of their parent.
+	LeftRight
+	// RightLeft draws the root on the right and children on the left of their parent.
+	RightLeft
+)
+
+// valid reports whether o is one of the defined orientations.
+func (o Orientation) valid() bool {
+	return o >= TopDown && o <= RightLeft
+}
+
+// transposed reports whether o swaps rows and columns of the top down layout.
+func (o Orientation) transposed() bool {
+	return o == LeftRight || o == RightLeft
+}
+
+// orient returns the drawing d of a tree laid out top down in orientation o.
+func (o Orientation) orient(d *drawer.Drawer) *drawer.Drawer {
+	switch o {
+	case BottomUp:
+		return d.FlipVertical()
+	case LeftRight:
+		return d.Transpose()
+	case RightLeft:
+		return d.Transpose().FlipHorizontal()
+	}
+	return d
+}
+
+// unorient is the inverse of orient, values are unoriented in advance
+// so that they end up in their original orientation once the whole drawing is oriented.
+func (o Orientation) unorient(d *drawer.Drawer) *drawer.Drawer {
+	switch o {
+	case BottomUp:
+		return d.FlipVertical()
+	case LeftRight:
+		return d.Transpose()
+	case RightLeft:
+		return d.FlipHorizontal().Transpose()
+	}
+	return d
+}
+
+// orientRect returns where rect of a drawing w by h laid out top down ends up once the drawing is oriented.
+func (o Orientation) orientRect(rect drawer.Rect, w, h int) drawer.Rect {
+	switch o {
+	case BottomUp:
+		return drawer.Rect{X: rect.X, Y: h - rect.Y - rect.H, W: rect.W, H: rect.H}
+	case LeftRight:
+		return drawer.Rect{X: rect.Y, Y: rect.X, W: rect.H, H: rect.W}
+	case RightLeft:
+		return drawer.Rect{X: h - rect.Y - rect.H, Y: rect.X, W: rect.H, H: rect.W}
+	}
+	return rect
+}
+
+// unorientDividers returns where the rows of dividers of a value h rows high end up once the value is unoriented,
+// as rows and columns of dividers.
+func (o Orientation) unorientDividers(dividers []int, h int) (rows, columns []int) {
+	switch o {
+	case BottomUp:
+		rows = make([]int, len(dividers))
+		for i, y := range dividers {
+			rows[i] = h - 1 - y
+		}
+		return rows, nil
+	case LeftRight, RightLeft:
+		return nil, dividers
+	}
+	return dividers, nil
+}
@@ -0,0 +1,383 @@
+package tree
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// parsedBox is a box found while parsing a drawing, with the position of its up left corner.
+type parsedBox struct {
+	x, y, w, h int
+	// sections holds the text inside the box, split by the dividers ├───┤ drawn across it
+	sections []string
+	// annotation is the text drawn in the top edge, like ╭─ 3 ─╮, or ""
+	annotation string
+	// parentLink and childrenLink are the columns of ┴ on the top edge and of ┬ on the bottom edge, or -1
+	parentLink, childrenLink int
+	children                 []*parsedBox
+	hasParent                bool
+}
+
+// drawingParser holds the state of ParseDrawing.
+type drawingParser struct {
+	grid [][]rune
+	// used marks the cells which belong to a box, to a label or to a connector
+	used [][]bool
+	// edges maps the cells of the top and bottom edges of each box to the box
+	edges map[[2]int]*parsedBox
+	boxes []*parsedBox
+}
+
+// ParseDrawing reads back the tree drawn by Tree.String, or by Render with the default style and orientation,
+// with a NodeString for each node holding the text inside its box, like Label returns it,
+// or a NodeSections for each box divided into sections:
+// since trailing spaces aren't distinguishable from the padding of the box, they are removed from each line.
+// Annotations drawn in the top edge of boxes are restored with SetAnnotation:
+// since boxes are widened to make room for them, the spaces which begin every line of an annotated box are removed too.
+// Children are added in the order in which they appear from left to right.
+// Returns an error with the line and the column, counted from 1, of the first rune
+// which doesn't belong to a box or to a connector, or which breaks one of them.
+func ParseDrawing(s string) (*Tree, error) {
+	p := newDrawingParser(s)
+	if err := p.findBoxes(); err != nil {
+		return nil, err
+	}
+	if len(p.boxes) == 0 {
+		return nil, fmt.Errorf("there is no node to read")
+	}
+
+	for _, b := range p.boxes {
+		if b.parentLink < 0 {
+			continue
+		}
+		parent, err := p.followParentLink(b)
+		if err != nil {
+			return nil, err
+		}
+		parent.children = append(parent.children, b)
+		b.hasParent = true
+	}
+
+	var root *parsedBox
+	for _, b := range p.boxes {
+		if b.childrenLink >= 0 && len(b.children) == 0 {
+			return nil, fmt.Errorf("malformed connector at %s: ┬ doesn't lead to any child", position(b.childrenLink, b.y+b.h-1))
+		}
+		if b.hasParent {
+			continue
+		}
+		if root != nil {
+			return nil, fmt.Errorf("the boxes at %s and at %s have no parent, there can be only one root", position(root.x, root.y), position(b.x, b.y))
+		}
+		root = b
+	}
+
+	for y, row := range p.grid {
+		for x, r := range row {
+			if r != ' ' && !p.used[y][x] {
+				return nil, fmt.Errorf("unexpected %q at %s outside boxes and connectors", r, position(x, y))
+			}
+		}
+	}
+
+	return root.toTree(), nil
+}
+
+// position returns the line and column of the cell x, y, counted from 1, ready to be put in an error message.
+func position(x, y int) string {
+	return fmt.Sprintf("line %d, column %d", y+1, x+1)
+}
+
+// newDrawingParser splits s into a grid of runes, padding the rows with spaces to the same width.
+func newDrawingParser(s string) *drawingParser {
+	lines := strings.Split(strings.TrimSuffix(strings.ReplaceAll(s, "\r\n", "\n"), "\n"), "\n")
+	w := 0
+	grid := make([][]rune, len(lines))
+	for y, line := range lines {
+		grid[y] = []rune(line)
+		if len(grid[y]) > w {
+			w = len(grid[y])
+		}
+	}
+	used := make([][]bool, len(grid))
+	for y := range grid {
+		for len(grid[y]) < w {
+			grid[y] = append(grid[y], ' ')
+		}
+		used[y] = make([]bool, w)
+	}
+	return &drawingParser{grid: grid, used: used, edges: make(map[[2]int]*parsedBox)}
+}
+
+// at returns the rune in position x, y, or a space outside the grid.
+func (p *drawingParser) at(x, y int) rune {
+	if y < 0 || y >= len(p.grid) || x < 0 || x >= len(p.grid[y]) {
+		return ' '
+	}
+	return p.grid[y][x]
+}
+
+// findBoxes looks for boxes row by row, the runes inside a box are part of its label
+// and they are never taken as the corner of another box.
+// A ╭ with │ below starts a box, any other ╭ must be the left end of a connector.
+func (p *drawingParser) findBoxes() error {
+	for y, row := range p.grid {
+		for x, r := range row {
+			if r != '╭' || p.used[y][x] || p.at(x, y+1) != '│' {
+				continue
+			}
+			b, err := p.readBox(x, y)
+			if err != nil {
+				return err
+			}
+			p.boxes = append(p.boxes, b)
+		}
+	}
+	return nil
+}
+
+// readBox reads the box with the up left corner in position x, y and marks its cells as used.
+func (p *drawingParser) readBox(x, y int) (*parsedBox, error) {
+	b := &parsedBox{x: x, y: y, parentLink: -1, childrenLink: -1}
+
+	// Following the top edge up to ╮
+	endX := x + 1
+	for ; p.at(endX, y) != '╮'; endX++ {
+		switch p.at(endX, y) {
+		case '─':
+		case '┴':
+			if b.parentLink >= 0 {
+				return nil, fmt.Errorf("malformed box at %s: a second ┴ on the top edge", position(endX, y))
+			}
+			b.parentLink = endX
+		case ' ':
+			// The annotation, with a space on both sides, goes at the end of the top edge before a ─
+			closing := endX + 1
+			for closing < len(p.grid[y]) && p.grid[y][closing] != '╮' {
+				closing++
+			}
+			if b.annotation != "" || p.at(closing, y) != '╮' || closing-endX < 4 || p.at(closing-2, y) != ' ' || p.at(closing-1, y) != '─' {
+				return nil, fmt.Errorf("malformed box at %s: unexpected ' ' on the top edge outside an annotation", position(endX, y))
+			}
+			b.annotation = string(p.grid[y][endX+1 : closing-2])
+			endX = closing - 1
+		default:
+			return nil, fmt.Errorf("malformed box at %s: unexpected %q on the top edge", position(endX, y), p.at(endX, y))
+		}
+	}
+	// Following the left edge down to ╰
+	endY := y + 1
+	var dividers []int
+	for ; p.at(x, endY) != '╰'; endY++ {
+		switch p.at(x, endY) {
+		case '│':
+		case '├':
+			dividers = append(dividers, endY)
+		default:
+			return nil, fmt.Errorf("malformed box at %s: unexpected %q on the left edge", position(x, endY), p.at(x, endY))
+		}
+	}
+	b.w, b.h = endX-x+1, endY-y+1
+
+	// Checking the right and bottom edges, and the dividers across the box
+	d := 0
+	for i := y + 1; i < endY; i++ {
+		if d < len(dividers) && dividers[d] == i {
+			for j := x + 1; j < endX; j++ {
+				if p.at(j, i) != '─' {
+					return nil, fmt.Errorf("malformed box at %s: unexpected %q on a divider", position(j, i), p.at(j, i))
+				}
+			}
+			if p.at(endX, i) != '┤' {
+				return nil, fmt.Errorf("malformed box at %s: unexpected %q at the end of a divider", position(endX, i), p.at(endX, i))
+			}
+			d++
+			continue
+		}
+		if p.at(endX, i) != '│' {
+			return nil, fmt.Errorf("malformed box at %s: unexpected %q on the right edge", position(endX, i), p.at(endX, i))
+		}
+	}
+	if p.at(endX, endY) != '╯' {
+		return nil, fmt.Errorf("malformed box at %s: unexpected %q in the bottom right corner", position(endX, endY), p.at(endX, endY))
+	}
+	for i := x + 1; i < endX; i++ {
+		switch p.at(i, endY) {
+		case '─':
+		case '┬':
+			if b.childrenLink >= 0 {
+				return nil, fmt.Errorf("malformed box at %s: a second ┬ on the bottom edge", position(i, endY))
+			}
+			b.childrenLink = i
+		default:
+			return nil, fmt.Errorf("malformed box at %s: unexpected %q on the bottom edge", position(i, endY), p.at(i, endY))
+		}
+	}
+
+	var rows []string
+	for i := y + 1; i < endY; i++ {
+		if p.at(x, i) == '├' {
+			b.sections = append(b.sections, strings.Join(rows, "\n"))
+			rows = nil
+			continue
+		}
+		rows = append(rows, strings.TrimRight(string(p.grid[i][x+1:endX]), " "))
+	}
+	b.sections = append(b.sections, strings.Join(rows, "\n"))
+	if b.annotation != "" {
+		b.sections = unindent(b.sections)
+	}
+
+	for i := y; i <= endY; i++ {
+		for j := x; j <= endX; j++ {
+			p.used[i][j] = true
+		}
+	}
+	for j := x; j <= endX; j++ {
+		p.edges[[2]int{j, y}] = b
+		p.edges[[2]int{j, endY}] = b
+	}
+	return b, nil
+}
+
+// unindent removes from the lines of sections the spaces with which all of them begin, ignoring empty lines.
+func unindent(sections []string) []string {
+	indent := -1
+	for _, section := range sections {
+		for _, line := range strings.Split(section, "\n") {
+			if line == "" {
+				continue
+			}
+			if n := len(line) - len(strings.TrimLeft(line, " ")); indent < 0 || n < indent {
+				indent = n
+			}
+		}
+	}
+	if indent <= 0 {
+		return sections
+	}
+	unindented := make([]string, len(sections))
+	for i, section := range sections {
+		lines := strings.Split(section, "\n")
+		for j, line := range lines {
+			if line != "" {
+				lines[j] = line[indent:]
+			}
+		}
+		unindented[i] = strings.Join(lines, "\n")
+	}
+	return unindented
+}
+
+// followParentLink follows the connector which leaves b from the ┴ on its top edge
+// and returns the box it leads to, marking the cells of the connector as used.
+// The connector is either a pipe going straight up to the ┬ of the parent, when b is an only child,
+// or a horizontal line joining the children of the same parent, with the parent right above its ┴,
+// or above the ├ or ┤ at one of its ends when the parent is aligned with its first or last child,
+// which may be joined to the parent by a pipe.
+func (p *drawingParser) followParentLink(b *parsedBox) (*parsedBox, error) {
+	x, y := b.parentLink, b.y-1
+	for p.at(x, y) == '│' && !p.used[y][x] {
+		p.used[y][x] = true
+		y--
+	}
+
+	switch p.at(x, y) {
+	case '┬':
+		if parent := p.edges[[2]int{x, y}]; parent != nil && parent.childrenLink == x {
+			return parent, nil
+		}
+		if y != b.y-1 {
+			return nil, fmt.Errorf("malformed connector at %s: the pipe doesn't end on the bottom edge of a box", position(x, y))
+		}
+	case '╭', '╮', '┼', '├', '┤':
+		if y != b.y-1 {
+			return nil, fmt.Errorf("malformed connector at %s: unexpected %q at the end of the pipe", position(x, y), p.at(x, y))
+		}
+	default:
+		return nil, fmt.Errorf("malformed connector at %s: unexpected %q above ┴", position(x, y), p.at(x, y))
+	}
+
+	// Reading the horizontal line from its left end, which is ├ instead of ╭ when the parent is right above it
+	start := x
+	for p.at(start, y) != '╭' && p.at(start, y) != '├' {
+		start--
+		if r := p.at(start, y); !strings.ContainsRune("╭├─┬┴┼", r) {
+			return nil, fmt.Errorf("malformed connector at %s: unexpected %q while looking for ╭", position(start, y), r)
+		}
+	}
+	parentX := -1
+	if p.at(start, y) == '├' {
+		parentX = start
+	}
+	end := start + 1
+	for ; p.at(end, y) != '╮' && p.at(end, y) != '┤'; end++ {
+		switch r := p.at(end, y); r {
+		case '─', '┬':
+		case '┴', '┼':
+			if parentX >= 0 {
+				return nil, fmt.Errorf("malformed connector at %s: a second %c joining the parent", position(end, y), r)
+			}
+			parentX = end
+		default:
+			return nil, fmt.Errorf("malformed connector at %s: unexpected %q while looking for ╮", position(end, y), r)
+		}
+	}
+	if p.at(end, y) == '┤' {
+		if parentX >= 0 {
+			return nil, fmt.Errorf("malformed connector at %s: a second ┤ joining the parent", position(end, y))
+		}
+		parentX = end
+	}
+	if parentX < 0 {
+		return nil, fmt.Errorf("malformed connector at %s: the line has no ┴ joining the parent", position(start, y))
+	}
+	// Every rune going down must lead to a child
+	for i := start; i <= end; i++ {
+		switch p.at(i, y) {
+		case '╭', '╮', '┬', '┼', '├', '┤':
+			if child := p.edges[[2]int{i, y + 1}]; child == nil || child.parentLink != i {
+				return nil, fmt.Errorf("malformed connector at %s: %c doesn't lead to any child", position(i, y), p.at(i, y))
+			}
+		}
+		p.used[y][i] = true
+	}
+
+	// Following the pipe which goes up to the parent, it is longer than a row in rank-aligned drawings
+	// and it is shared by the children of the same parent
+	for y--; p.at(parentX, y) == '│'; y-- {
+		p.used[y][parentX] = true
+	}
+	parent := p.edges[[2]int{parentX, y}]
+	if parent == nil || parent.childrenLink != parentX {
+		return nil, fmt.Errorf("malformed connector at %s: there is no ┬ of a parent above", position(parentX, y))
+	}
+	return parent, nil
+}
+
+// value returns the value of the node drawn in b, NodeSections if b is divided into sections.
+func (b *parsedBox) value() NodeValue {
+	if len(b.sections) == 1 {
+		return NodeString(b.sections[0])
+	}
+	return NodeSections(b.sections)
+}
+
+// toTree builds the tree rooted at b, with children ordered from left to right.
+func (b *parsedBox) toTree() *Tree {
+	t := NewTree(b.value())
+	t.SetAnnotation(b.annotation)
+	b.addChildrenTo(t)
+	return t
+}
+
+// addChildrenTo adds the subtrees rooted at the children of b to t, from left to right.
+func (b *parsedBox) addChildrenTo(t *Tree) {
+	sort.Slice(b.children, func(i, j int) bool { return b.children[i].x < b.children[j].x })
+	for _, child := range b.children {
+		c := t.AddChild(child.value())
+		c.SetAnnotation(child.annotation)
+		child.addChildrenTo(c)
+	}
+}
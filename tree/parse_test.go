@@ -0,0 +1,151 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDrawing(t *testing.T) {
+	wide := NewTree(NodeString("a very long root"))
+	wide.AddChild(NodeString("b"))
+	wide.AddChild(NodeString("c")).AddChild(NodeString("d"))
+
+	labels := NewTree(NodeString("╭─╮\n│x│"))
+	labels.AddChild(NodeString("two\nlines\n"))
+	labels.AddChild(NodeString("ünï"))
+	labels.AddChild(NodeString("┬"))
+
+	chain := NewTree(NodeInt64(1))
+	chain.AddChild(NodeInt64(2)).AddChild(NodeInt64(3)).AddChild(NodeInt64(4))
+
+	for _, tr := range []*Tree{traversalTree(), wide, labels, chain, NewTree(NodeString("alone"))} {
+		s := tr.String()
+		parsed, err := ParseDrawing(s)
+		if err != nil {
+			t.Errorf("the drawing should be parsed: %v\n%s", err, s)
+			continue
+		}
+		checkParents(t, parsed)
+		if parsed.String() != s {
+			t.Errorf("expected the parsed tree to be drawn like\n%s\nreceived\n%s", s, parsed.String())
+		}
+		original, read := preOrderValues(tr), preOrderValues(parsed)
+		if len(original) != len(read) {
+			t.Errorf("expected %d nodes, received %d", len(original), len(read))
+			continue
+		}
+		for i, n := range read {
+			if n != NodeString(Label(original[i])) {
+				t.Errorf("expected the label %q, received %q", Label(original[i]), n)
+			}
+		}
+	}
+}
+
+func TestParseDrawingAnnotations(t *testing.T) {
+	tr := NewTree(NodeString("root"))
+	tr.SetAnnotation("2 nodes")
+	a := tr.AddChild(NodeString("a"))
+	a.SetAnnotation("x")
+	a.AddChild(NodeSections{"b", "two\nlines"}).SetAnnotation("long annotation")
+	tr.AddChild(NodeString("a label wider than its annotation")).SetAnnotation("y")
+
+	s := tr.String()
+	parsed, err := ParseDrawing(s)
+	if err != nil {
+		t.Fatalf("the drawing should be parsed: %v\n%s", err, s)
+	}
+	checkParents(t, parsed)
+	if parsed.String() != s {
+		t.Errorf("expected the parsed tree to be drawn like\n%s\nreceived\n%s", s, parsed.String())
+	}
+	var original, read []*Tree
+	tr.Walk(func(n *Tree) error {
+		original = append(original, n)
+		return nil
+	})
+	parsed.Walk(func(n *Tree) error {
+		read = append(read, n)
+		return nil
+	})
+	if len(original) != len(read) {
+		t.Fatalf("expected %d nodes, received %d", len(original), len(read))
+	}
+	for i, n := range read {
+		if Label(n.Val()) != Label(original[i].Val()) {
+			t.Errorf("expected the label %q, received %q", Label(original[i].Val()), Label(n.Val()))
+		}
+		if n.Annotation() != original[i].Annotation() {
+			t.Errorf("expected the annotation %q, received %q", original[i].Annotation(), n.Annotation())
+		}
+	}
+}
+
+func TestParseDrawingErrors(t *testing.T) {
+	tests := []struct {
+		drawing  []string
+		position string
+	}{
+		{[]string{""}, ""},
+		{[]string{
+			"╭─╮",
+			"│a│",
+			"╰─╯",
+			"  x",
+		}, "line 4, column 3"},
+		{[]string{
+			"╭─ a╮",
+			"│b  │",
+			"╰───╯",
+		}, "line 1, column 3"},
+		{[]string{
+			"╭─╮",
+			"│a│",
+			"╰┬╯",
+			" │ ",
+		}, "line 3, column 2"},
+		{[]string{
+			"  ╭─╮  ",
+			"  │a│  ",
+			"  ╰┬╯  ",
+			"╭──┴─╮ ",
+			" ╭─╮╭┴╮",
+			" │b││c│",
+			" ╰─╯╰─╯",
+		}, "line 4, column 1"},
+		{[]string{
+			"╭─╮",
+			"│a│",
+			"╰┬╯",
+			" x ",
+			"╭┴╮",
+			"│b│",
+			"╰─╯",
+		}, "line 4, column 2"},
+		{[]string{
+			"  ╭─╮  ",
+			"  │a│  ",
+			"  ╰┬╯  ",
+			" ╭━┴─╮ ",
+			"╭┴╮ ╭┴╮",
+			"│b│ │c│",
+			"╰─╯ ╰─╯",
+		}, "line 4, column 3"},
+		{[]string{
+			"╭─╮ ╭─╮",
+			"│a│ │b│",
+			"╰─╯ ╰─╯",
+		}, "line 1, column 5"},
+	}
+	for _, test := range tests {
+		s := strings.Join(test.drawing, "\n")
+		_, err := ParseDrawing(s)
+		if err == nil {
+			t.Errorf("expected an error for\n%s", s)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.position) {
+			t.Errorf("expected an error at %s, received %v", test.position, err)
+		}
+	}
+}
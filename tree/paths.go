@@ -0,0 +1,82 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathsOptions controls how FromPaths merges paths into a tree.
+// The zero value gives a node for each component of the paths.
+type PathsOptions struct {
+	// Compress joins each node with a single child to its child, like a/b,
+	// unless a path ends in the node.
+	Compress bool
+	// Counts appends to the label of each leaf the number of paths ending in it, like c (2).
+	Counts bool
+}
+
+// FromPaths returns the prefix tree of paths, in which the components of each path,
+// separated by sep, are the children of the previous ones and paths with the same prefix share its nodes.
+// The root is labelled with sep and children are kept in the order of the first path they appear in.
+// Empty components, like the one before a leading sep, are ignored.
+// Returns an error if sep is empty.
+func FromPaths(paths []string, sep string, opts PathsOptions) (*Tree, error) {
+	if sep == "" {
+		return nil, fmt.Errorf("the separator of paths can't be empty")
+	}
+
+	root := NewTree(NodeString(sep))
+	// index maps each node to its children by label, to merge long lists of paths quickly
+	index := make(map[*Tree]map[string]*Tree)
+	// ends counts the paths ending in each node
+	ends := make(map[*Tree]int)
+	for _, path := range paths {
+		t := root
+		for _, component := range strings.Split(path, sep) {
+			if component == "" {
+				continue
+			}
+			child, ok := index[t][component]
+			if !ok {
+				child = t.AddChild(NodeString(component))
+				if index[t] == nil {
+					index[t] = make(map[string]*Tree)
+				}
+				index[t][component] = child
+			}
+			t = child
+		}
+		ends[t]++
+	}
+
+	if opts.Compress {
+		for _, c := range root.children {
+			compressPaths(c, sep, ends)
+		}
+	}
+	if opts.Counts {
+		for _, l := range root.Leaves() {
+			if ends[l] > 0 {
+				l.val = NodeString(fmt.Sprintf("%s (%d)", Label(l.val), ends[l]))
+			}
+		}
+	}
+	return root, nil
+}
+
+// compressPaths joins t and each of its descendants with a single child to their child,
+// if no path ends in them according to ends.
+func compressPaths(t *Tree, sep string, ends map[*Tree]int) {
+	for len(t.children) == 1 && ends[t] == 0 {
+		child := t.children[0]
+		t.val = NodeString(Label(t.val) + sep + Label(child.val))
+		t.children = child.children
+		for _, grandchild := range t.children {
+			grandchild.parent = t
+		}
+		ends[t] = ends[child]
+	}
+	for _, c := range t.children {
+		compressPaths(c, sep, ends)
+	}
+}
@@ -0,0 +1,54 @@
+package tree
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFromPaths(t *testing.T) {
+	paths := []string{"a/b/c", "a/b/d", "/a/e", "f", "a/b/c"}
+	tests := []struct {
+		opts     PathsOptions
+		expected string
+	}{
+		{PathsOptions{}, "/\n├── a\n│   ├── b\n│   │   ├── c\n│   │   ╰── d\n│   ╰── e\n╰── f\n"},
+		{PathsOptions{Counts: true}, "/\n├── a\n│   ├── b\n│   │   ├── c (2)\n│   │   ╰── d (1)\n│   ╰── e (1)\n╰── f (1)\n"},
+	}
+	for _, test := range tests {
+		tr, err := FromPaths(paths, "/", test.opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkParents(t, tr)
+		s, err := tr.Outline(RenderOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s != test.expected {
+			t.Errorf("%+v: expected:\n%s\nreceived:\n%s", test.opts, test.expected, s)
+		}
+	}
+}
+
+func TestFromPathsCompress(t *testing.T) {
+	paths := []string{"src.main.go.App", "src.main.go.util.Strings", "src.test", "docs.api.index", "docs.api"}
+	tr, err := FromPaths(paths, ".", PathsOptions{Compress: true, Counts: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkParents(t, tr)
+	s, err := tr.Outline(RenderOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// docs.api doesn't absorb index since a path ends in it
+	expected := ".\n├── src\n│   ├── main.go\n│   │   ├── App (1)\n│   │   ╰── util.Strings (1)\n│   ╰── test (1)\n╰── docs.api\n    ╰── index (1)\n"
+	if s != expected {
+		t.Errorf("expected:\n%s\nreceived:\n%s", expected, s)
+	}
+	fmt.Println(tr)
+
+	if _, err := FromPaths(paths, "", PathsOptions{}); err == nil {
+		t.Errorf("expected error with empty separator, received nil")
+	}
+}
@@ -0,0 +1,485 @@
+package tree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/m1gwings/treedrawer/drawer"
+)
+
+// minWrapWidth is the narrowest width to which labels get wrapped while trying to fit RenderOptions.MaxWidth.
+const minWrapWidth = 10
+
+// RenderOptions controls how a tree is drawn by Render.
+// The zero value draws the whole tree, exactly like String does.
+type RenderOptions struct {
+	// MaxDepth is the maximum number of layers drawn below the root,
+	// the children of the nodes in the last layer are replaced by a "… (+N nodes)" leaf.
+	// A value of 0 means no limit.
+	MaxDepth int
+	// MaxChildren is the maximum number of children drawn for each node,
+	// the remaining children are replaced by a "… (+N nodes)" leaf.
+	// A value of 0 means no limit.
+	MaxChildren int
+	// MaxWidth is the maximum number of columns of the drawing.
+	// When the tree is wider, Render tries in order to wrap NodeString labels,
+	// to switch the widest subtrees to the outline layout
+	// and finally to split the drawing into pages which are MaxWidth columns wide.
+	// A value of 0 means no limit.
+	MaxWidth int
+	// NoPages makes Render return an error instead of splitting the drawing into pages.
+	NoPages bool
+	// Style is the set of runes used to draw boxes and connections,
+	// the zero value means drawer.RoundedStyle.
+	Style drawer.Style
+	// Orientation is where the root is drawn with respect to its descendants.
+	Orientation Orientation
+	// Highlight reports whether a node is highlighted: its box is drawn with the lines of HighlightStyle,
+	// together with the connections on the path from the root to it, so that mixed junctions like ╀ appear
+	// where the path leaves the other connections. HighlightNodes returns a Highlight for a set of nodes.
+	// Nodes which are hidden or drawn in the outline layout are not highlighted.
+	// A nil Highlight highlights no node.
+	Highlight func(*Tree) bool
+	// HighlightStyle gives the weight of the lines of highlighted nodes and paths,
+	// the zero value means drawer.HeavyStyle.
+	HighlightStyle drawer.Style
+	// AnnotationPosition is where the annotations of nodes are drawn, the zero value means AnnotationInBorder.
+	AnnotationPosition AnnotationPosition
+	// ParentAlign is where nodes are drawn with respect to their children, the zero value means ParentCenter.
+	// Tree.SetParentAlign overrides it for a single node.
+	ParentAlign ParentAlign
+	// TextAlign is how the lines of NodeString and NodeSections values are aligned inside their boxes,
+	// the zero value means drawer.AlignLeft. Tree.SetTextAlign overrides it for a single node.
+	TextAlign drawer.Align
+	// RankAligned draws all the nodes at the same depth starting on the same row,
+	// extending the pipes under the boxes which are shorter than the tallest one at their depth,
+	// so that the levels of the tree are easy to see.
+	RankAligned bool
+	// Output controls how the drawing is converted to text by Render and Fprint,
+	// for example to trim the trailing spaces of each row.
+	Output drawer.OutputOptions
+}
+
+// renderer holds the options used to draw a tree
+// and the state of the strategies used to fit the drawing in opts.MaxWidth.
+type renderer struct {
+	opts RenderOptions
+	// style is the style in opts with the default applied
+	style drawer.Style
+	// wrapWidth is the width to which NodeString labels are wrapped, 0 means no wrapping
+	wrapWidth int
+	// outline reports whether subtrees which don't fit get switched to the outline layout
+	outline bool
+	// highlightStyle is the highlight style in opts with the default applied
+	highlightStyle drawer.Style
+	// highlighted holds the nodes for which opts.Highlight is true and paths the nodes on the path to them,
+	// they are nil if no node is highlighted
+	highlighted, paths map[*Tree]bool
+	// ranks holds the height of the tallest value at each depth when opts.RankAligned is set, it is nil otherwise
+	ranks []int
+	// boxes and offsets are used by Layout to locate nodes, they are nil if nodes are not being located:
+	// boxes maps each node to the rectangle of its box inside the drawer of its subtree,
+	// offsets maps each node to the rectangle of the drawer of its subtree inside the drawer of its parent
+	boxes, offsets map[*Tree]drawer.Rect
+}
+
+// newRenderer returns a renderer for opts.
+// Returns an error if opts are not valid.
+func newRenderer(opts RenderOptions) (*renderer, error) {
+	if opts.MaxDepth < 0 || opts.MaxChildren < 0 || opts.MaxWidth < 0 {
+		return nil, fmt.Errorf("options must be non-negative, received %d %d %d", opts.MaxDepth, opts.MaxChildren, opts.MaxWidth)
+	}
+	if !opts.Orientation.valid() {
+		return nil, fmt.Errorf("unknown orientation %d", opts.Orientation)
+	}
+	if opts.AnnotationPosition != AnnotationInBorder && opts.AnnotationPosition != AnnotationRight {
+		return nil, fmt.Errorf("unknown annotation position %d", opts.AnnotationPosition)
+	}
+	if !opts.ParentAlign.valid() {
+		return nil, fmt.Errorf("unknown parent alignment %d", opts.ParentAlign)
+	}
+	if !validTextAlign(opts.TextAlign) {
+		return nil, fmt.Errorf("unknown text alignment %d", opts.TextAlign)
+	}
+	r := &renderer{opts: opts, style: opts.Style, highlightStyle: opts.HighlightStyle}
+	if r.style == (drawer.Style{}) {
+		r.style = drawer.RoundedStyle
+	}
+	if r.highlightStyle == (drawer.Style{}) {
+		r.highlightStyle = drawer.HeavyStyle
+	}
+	return r, nil
+}
+
+// Render returns the string representation of the tree drawn according to opts.
+// Like String, it always draws the whole tree starting from the root.
+// If the drawing has been split into pages, they are separated by an empty line.
+// Returns an error if opts are not valid or if the drawing can't fit opts.MaxWidth.
+func (t *Tree) Render(opts RenderOptions) (string, error) {
+	var b strings.Builder
+	err := Fprint(&b, t, opts)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Fprint writes the tree containing t to w, drawn according to opts exactly like Render,
+// a row at a time through a buffer, without building the whole string in memory.
+// Returns an error if opts are not valid, if the drawing can't fit opts.MaxWidth or if writing to w fails.
+func Fprint(w io.Writer, t *Tree, opts RenderOptions) error {
+	r, err := newRenderer(opts)
+	if err != nil {
+		return err
+	}
+	pages, err := r.draw(t.Root())
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	for i, page := range pages {
+		if i > 0 {
+			bw.WriteByte('\n')
+		}
+		pageOpts := r.opts.Output
+		if i < len(pages)-1 {
+			// Only the last page can omit the final newline, the others are followed by an empty line
+			pageOpts.NoFinalNewline = false
+		}
+		_, err := page.Fprint(bw, pageOpts)
+		if err != nil {
+			return fmt.Errorf("error while writing the tree: %v", err)
+		}
+	}
+	err = bw.Flush()
+	if err != nil {
+		return fmt.Errorf("error while writing the tree: %v", err)
+	}
+	return nil
+}
+
+// Canvas returns the drawer on which the tree is drawn according to opts, starting from the root.
+// The drawing is never split into pages, so it can be wider than opts.MaxWidth
+// if wrapping labels and switching to the outline layout are not enough,
+// use drawer.Drawer.Tiles to print it in pieces.
+// Returns an error if opts are not valid.
+func (t *Tree) Canvas(opts RenderOptions) (*drawer.Drawer, error) {
+	r, err := newRenderer(opts)
+	if err != nil {
+		return nil, err
+	}
+	return r.fit(t.Root()), nil
+}
+
+// Layout returns the drawer on which the tree is drawn according to opts, exactly like Canvas,
+// together with the rectangle covered by the box of each drawn node.
+// Nodes which are hidden are not in the map, nodes drawn in the outline layout
+// are in the map only if they are the first node of their outline, with the rectangle of the whole outline.
+// Returns an error if opts are not valid.
+func (t *Tree) Layout(opts RenderOptions) (*drawer.Drawer, map[*Tree]drawer.Rect, error) {
+	r, err := newRenderer(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.boxes, r.offsets = make(map[*Tree]drawer.Rect), make(map[*Tree]drawer.Rect)
+	root := t.Root()
+	d := r.fit(root)
+	// w and h are the dimensions of the drawing laid out top down, before orienting it
+	w, h := d.Dimens()
+	if r.opts.Orientation.transposed() {
+		w, h = h, w
+	}
+
+	// Moving each box from the drawer of its subtree to the drawer of the whole tree,
+	// the walk is in pre-order so that parents are processed before their children
+	boxes := make(map[*Tree]drawer.Rect)
+	subtrees := map[*Tree]drawer.Rect{root: {}}
+	root.Walk(func(n *Tree) error {
+		subtree, ok := subtrees[n]
+		box, placed := r.boxes[n]
+		if !ok || !placed {
+			return SkipSubtree
+		}
+		// Boxes have been placed before orienting the drawing
+		boxes[n] = r.opts.Orientation.orientRect(drawer.Rect{X: subtree.X + box.X, Y: subtree.Y + box.Y, W: box.W, H: box.H}, w, h)
+		for _, nChild := range n.children {
+			if offset, ok := r.offsets[nChild]; ok {
+				subtrees[nChild] = drawer.Rect{X: subtree.X + offset.X, Y: subtree.Y + offset.Y, W: offset.W, H: offset.H}
+			}
+		}
+		return nil
+	})
+	return d, boxes, nil
+}
+
+// place records the placement p of the box of t and of the drawers of children, if nodes are being located.
+func (r *renderer) place(t *Tree, children []*Tree, p placement) {
+	if r.boxes == nil {
+		return
+	}
+	r.boxes[t] = p.box
+	for i, tChild := range children {
+		r.offsets[tChild] = p.children[i]
+	}
+}
+
+// placeOutline records that the subtree rooted at t has been drawn in d in the outline layout,
+// if nodes are being located.
+func (r *renderer) placeOutline(t *Tree, d *drawer.Drawer) {
+	if r.boxes == nil {
+		return
+	}
+	// Forgetting the nodes below t, since they aren't drawn in their own boxes anymore
+	t.Walk(func(n *Tree) error {
+		delete(r.boxes, n)
+		return nil
+	})
+	w, h := d.Dimens()
+	r.boxes[t] = drawer.Rect{X: 0, Y: 0, W: w, H: h}
+}
+
+// draw draws the tree rooted at t with fit and splits it into pages if it doesn't fit opts.MaxWidth.
+// Returns the pages of the drawing, there is only one page if the drawing fits.
+func (r *renderer) draw(t *Tree) ([]*drawer.Drawer, error) {
+	d := r.fit(t)
+	w, h := d.Dimens()
+	if r.opts.MaxWidth == 0 || w <= r.opts.MaxWidth {
+		return []*drawer.Drawer{d}, nil
+	}
+
+	// Splitting the drawing into pages
+	if r.opts.NoPages {
+		return nil, fmt.Errorf("the drawing is %d columns wide and doesn't fit in %d columns", w, r.opts.MaxWidth)
+	}
+	var pages []*drawer.Drawer
+	for x := 0; x < w; x += r.opts.MaxWidth {
+		pageW := r.opts.MaxWidth
+		if x+pageW > w {
+			pageW = w - x
+		}
+		page, err := d.Viewport(drawer.Rect{X: x, Y: 0, W: pageW, H: h})
+		if err != nil {
+			return nil, fmt.Errorf("error while extracting page at column %d: %v", x, err)
+		}
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// fit draws the tree rooted at t with fitWidth and orients the drawing according to opts.Orientation.
+func (r *renderer) fit(t *Tree) *drawer.Drawer {
+	r.highlight(t)
+	return r.opts.Orientation.orient(r.fitWidth(t))
+}
+
+// fitWidth draws the tree rooted at t trying the strategies to fit opts.MaxWidth one after the other.
+// Returns the drawing obtained with the last strategy if none of them fits.
+func (r *renderer) fitWidth(t *Tree) *drawer.Drawer {
+	d := r.stringifyRoot(t)
+	if r.fits(d) {
+		return d
+	}
+
+	// Wrapping labels to narrower and narrower widths,
+	// the first width ensures that each box fits on its own (considering the box and odd width)
+	r.wrapWidth = r.opts.MaxWidth - 3
+	if r.wrapWidth < 1 {
+		r.wrapWidth = 1
+	}
+	for {
+		d = r.stringifyRoot(t)
+		if r.fits(d) {
+			return d
+		}
+		if r.wrapWidth <= minWrapWidth {
+			break
+		}
+		r.wrapWidth = r.wrapWidth * 2 / 3
+		if r.wrapWidth < minWrapWidth {
+			r.wrapWidth = minWrapWidth
+		}
+	}
+
+	// Switching subtrees to the outline layout
+	r.outline = true
+	return r.stringifyRoot(t)
+}
+
+// stringifyRoot draws the tree rooted at t with stringify, measuring the ranks of the tree first if opts.RankAligned is set.
+func (r *renderer) stringifyRoot(t *Tree) *drawer.Drawer {
+	if r.opts.RankAligned {
+		r.ranks = r.ranks[:0]
+		r.measureRanks(t, 0)
+	}
+	d, _ := r.stringify(t, 0)
+	return d
+}
+
+// measureRanks records in ranks the height of the tallest value at each depth of the tree rooted at t,
+// which is depth edges below the root, as compose draws it.
+// This function is called recursively
+func (r *renderer) measureRanks(t *Tree, depth int) {
+	w, h := r.annotatedVal(t, depth).d.Dimens()
+	if r.opts.Orientation.transposed() {
+		h = w
+	}
+	if depth == len(r.ranks) {
+		r.ranks = append(r.ranks, 0)
+	}
+	if h > r.ranks[depth] {
+		r.ranks[depth] = h
+	}
+	for _, tChild := range r.children(t, depth) {
+		r.measureRanks(tChild, depth+1)
+	}
+}
+
+// fits reports whether d, laid out top down, fits opts.MaxWidth once it is oriented.
+func (r *renderer) fits(d *drawer.Drawer) bool {
+	w, h := d.Dimens()
+	if r.opts.Orientation.transposed() {
+		w = h
+	}
+	return r.opts.MaxWidth == 0 || w <= r.opts.MaxWidth
+}
+
+// boxVal returns the drawer of n, wrapped by wrapVal and with its lines aligned according to align
+// if it is a NodeString or NodeSections, together with the dividers of its box.
+func (r *renderer) boxVal(n NodeValue, align drawer.Align) boxed {
+	n = r.wrapVal(n)
+	switch v := n.(type) {
+	case NodeString:
+		return boxed{d: alignText(string(v), align)}
+	case NodeSections:
+		return boxed{d: alignText(v.text(), align), dividers: v.dividers()}
+	}
+	return boxed{d: n.Draw()}
+}
+
+// wrapVal returns n wrapped to wrapWidth if it is a NodeString or NodeSections, n as is otherwise.
+func (r *renderer) wrapVal(n NodeValue) NodeValue {
+	if r.wrapWidth <= 0 {
+		return n
+	}
+	switch v := n.(type) {
+	case NodeString:
+		return NodeString(wrap(string(v), r.wrapWidth))
+	case NodeSections:
+		wrapped := make(NodeSections, len(v))
+		for i, section := range v {
+			wrapped[i] = wrap(section, r.wrapWidth)
+		}
+		return wrapped
+	}
+	return n
+}
+
+// children returns the children of t to draw, given that t is depth edges below the root.
+// Hidden children are replaced by a single ellipsis leaf.
+func (r *renderer) children(t *Tree, depth int) []*Tree {
+	if len(t.children) == 0 {
+		return nil
+	}
+	if t.collapsed || (r.opts.MaxDepth > 0 && depth >= r.opts.MaxDepth) {
+		return []*Tree{ellipsis(t.children)}
+	}
+	if r.opts.MaxChildren > 0 && len(t.children) > r.opts.MaxChildren {
+		visible := make([]*Tree, r.opts.MaxChildren, r.opts.MaxChildren+1)
+		copy(visible, t.children)
+		return append(visible, ellipsis(t.children[r.opts.MaxChildren:]))
+	}
+	return t.children
+}
+
+// ellipsis returns a synthetic leaf standing for the hidden subtrees, which reports how many nodes are hidden.
+func ellipsis(hidden []*Tree) *Tree {
+	n := 0
+	for _, tHidden := range hidden {
+		n += tHidden.Size()
+	}
+	if n == 1 {
+		return NewTree(NodeString("… (+1 node)"))
+	}
+	return NewTree(NodeString(fmt.Sprintf("… (+%d nodes)", n)))
+}
+
+// Outline returns the tree drawn in the outline layout according to opts, starting from the root:
+// each node takes a line, with its children in the following lines, indented and connected by lines.
+// opts.MaxWidth and opts.Orientation are ignored.
+// Returns an error if opts are not valid.
+func (t *Tree) Outline(opts RenderOptions) (string, error) {
+	r, err := newRenderer(opts)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	r.writeOutline(&b, t.Root(), 0, "", "")
+	return b.String(), nil
+}
+
+// boxedOutline draws the tree rooted at t, which is depth edges below the root, in the outline layout
+// and puts it inside a box, so that it can be connected to its parent like any other node.
+// Returns the drawn drawer and the column of the top edge of the box where it is linked to the parent.
+func (r *renderer) boxedOutline(t *Tree, depth int) (*drawer.Drawer, int) {
+	var b strings.Builder
+	r.writeOutline(&b, t, depth, "", "")
+	d, p := r.compose(boxed{d: NodeString(strings.TrimSuffix(b.String(), "\n")).Draw()}, nil, nil, ParentCenter)
+	return d, p.top()
+}
+
+// writeOutline writes the tree rooted at t onto b in the outline layout, one node per line
+// with its children below, indented and connected by pipes.
+// firstPrefix is written before the first line of the value of t and prefix before the other lines.
+// This function is called recursively
+func (r *renderer) writeOutline(b *strings.Builder, t *Tree, depth int, firstPrefix, prefix string) {
+	for i, line := range strings.Split(Label(r.wrapVal(t.val)), "\n") {
+		if i == 0 {
+			b.WriteString(firstPrefix)
+		} else {
+			b.WriteString(prefix)
+		}
+		b.WriteString(line)
+		if i == 0 && t.annotation != "" {
+			b.WriteString(" " + string(r.style.Horizontal) + " " + t.annotation)
+		}
+		b.WriteString("\n")
+	}
+
+	children := r.children(t, depth)
+	for i, tChild := range children {
+		if i == len(children)-1 {
+			r.writeOutline(b, tChild, depth+1, prefix+string([]rune{r.style.BottomLeft, r.style.Horizontal, r.style.Horizontal, ' '}), prefix+"    ")
+		} else {
+			r.writeOutline(b, tChild, depth+1, prefix+string([]rune{r.style.TeeRight, r.style.Horizontal, r.style.Horizontal, ' '}), prefix+string(r.style.Vertical)+"   ")
+		}
+	}
+}
+
+// wrap breaks each line of s into lines of at most width runes, breaking at spaces when possible.
+func wrap(s string, width int) string {
+	var wrapped []string
+	for _, line := range strings.Split(s, "\n") {
+		var current []rune
+		for _, word := range strings.Split(line, " ") {
+			wordRunes := []rune(word)
+			// Adding the word to the current line if it fits
+			if len(current) > 0 && len(current)+1+len(wordRunes) <= width {
+				current = append(append(current, ' '), wordRunes...)
+				continue
+			}
+			if len(current) > 0 {
+				wrapped = append(wrapped, string(current))
+			}
+			// Breaking words longer than width
+			for len(wordRunes) > width {
+				wrapped = append(wrapped, string(wordRunes[:width]))
+				wordRunes = wordRunes[width:]
+			}
+			current = wordRunes
+		}
+		wrapped = append(wrapped, string(current))
+	}
+	return strings.Join(wrapped, "\n")
+}
@@ -0,0 +1,554 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/m1gwings/treedrawer/drawer"
+)
+
+func TestRenderDefault(t *testing.T) {
+	tr := traversalTree()
+	s, err := tr.Render(RenderOptions{})
+	if err != nil {
+		t.Errorf("default options should be valid: %v", err)
+	}
+	if s != tr.String() {
+		t.Errorf("rendering with default options should be equal to String")
+	}
+
+	_, err = tr.Render(RenderOptions{MaxDepth: -1})
+	if err == nil {
+		t.Errorf("negative options shouldn't be accepted")
+	}
+}
+
+func TestRenderMaxDepth(t *testing.T) {
+	tr := traversalTree()
+	s, err := tr.Render(RenderOptions{MaxDepth: 1})
+	if err != nil {
+		t.Errorf("positive options should be valid: %v", err)
+	}
+	expected := strings.Join([]string{
+		"               ╭─╮               ",
+		"               │1│               ",
+		"               ╰┬╯               ",
+		"       ╭────────┴┬────────╮      ",
+		"      ╭┴╮       ╭┴╮      ╭┴╮     ",
+		"      │2│       │3│      │4│     ",
+		"      ╰┬╯       ╰─╯      ╰┬╯     ",
+		"       │                  │      ",
+		"╭──────┴─────╮      ╭─────┴─────╮",
+		"│… (+2 nodes)│      │… (+1 node)│",
+		"╰────────────╯      ╰───────────╯",
+		"",
+	}, "\n")
+	if s != expected {
+		t.Errorf("expected\n%s\nreceived\n%s", expected, s)
+	}
+}
+
+func TestRenderMaxChildren(t *testing.T) {
+	tr := traversalTree()
+	s, err := tr.Render(RenderOptions{MaxChildren: 1})
+	if err != nil {
+		t.Errorf("positive options should be valid: %v", err)
+	}
+	expected := strings.Join([]string{
+		"               ╭─╮               ",
+		"               │1│               ",
+		"               ╰┬╯               ",
+		"        ╭───────┴────────╮       ",
+		"       ╭┴╮        ╭──────┴─────╮ ",
+		"       │2│        │… (+3 nodes)│ ",
+		"       ╰┬╯        ╰────────────╯ ",
+		" ╭──────┴─╮                      ",
+		"╭┴╮ ╭─────┴─────╮                ",
+		"│5│ │… (+1 node)│                ",
+		"╰─╯ ╰───────────╯                ",
+		"",
+	}, "\n")
+	if s != expected {
+		t.Errorf("expected\n%s\nreceived\n%s", expected, s)
+	}
+}
+
+func TestRenderCollapsed(t *testing.T) {
+	tr := traversalTree()
+	t2, _ := tr.Child(0)
+	t2.SetCollapsed(true)
+	if !t2.Collapsed() {
+		t.Errorf("2 should be collapsed")
+	}
+	if !t2.Clone().Collapsed() {
+		t.Errorf("the clone of a collapsed node should be collapsed")
+	}
+
+	tests := []struct {
+		// path holds the indices of the children leading from the root to the collapsed node
+		path     []int
+		opts     RenderOptions
+		expected []string
+	}{
+		{[]int{0}, RenderOptions{}, []string{
+			"          ╭─╮          ",
+			"          │1│          ",
+			"          ╰┬╯          ",
+			"       ╭───┴─────┬───╮ ",
+			"      ╭┴╮       ╭┴╮ ╭┴╮",
+			"      │2│       │3│ │4│",
+			"      ╰┬╯       ╰─╯ ╰┬╯",
+			"       │             │ ",
+			"╭──────┴─────╮      ╭┴╮",
+			"│… (+2 nodes)│      │7│",
+			"╰────────────╯      ╰─╯",
+			"",
+		}},
+		// A collapsed leaf has nothing to hide
+		{[]int{0, 0}, RenderOptions{}, []string{
+			"      ╭─╮      ",
+			"      │1│      ",
+			"      ╰┬╯      ",
+			"   ╭───┴─┬───╮ ",
+			"  ╭┴╮   ╭┴╮ ╭┴╮",
+			"  │2│   │3│ │4│",
+			"  ╰┬╯   ╰─╯ ╰┬╯",
+			" ╭─┴─╮       │ ",
+			"╭┴╮ ╭┴╮     ╭┴╮",
+			"│5│ │6│     │7│",
+			"╰─╯ ╰─╯     ╰─╯",
+			"",
+		}},
+		// The children of 4 are hidden by MaxDepth too, they are counted once
+		{[]int{2}, RenderOptions{MaxDepth: 1}, []string{
+			"               ╭─╮               ",
+			"               │1│               ",
+			"               ╰┬╯               ",
+			"       ╭────────┴┬────────╮      ",
+			"      ╭┴╮       ╭┴╮      ╭┴╮     ",
+			"      │2│       │3│      │4│     ",
+			"      ╰┬╯       ╰─╯      ╰┬╯     ",
+			"       │                  │      ",
+			"╭──────┴─────╮      ╭─────┴─────╮",
+			"│… (+2 nodes)│      │… (+1 node)│",
+			"╰────────────╯      ╰───────────╯",
+			"",
+		}},
+	}
+	for _, test := range tests {
+		tr := traversalTree()
+		collapsed := tr
+		for _, i := range test.path {
+			collapsed, _ = collapsed.Child(i)
+		}
+		collapsed.SetCollapsed(true)
+
+		s, err := tr.Render(test.opts)
+		if err != nil {
+			t.Errorf("%+v: the options should be valid: %v", test.opts, err)
+			continue
+		}
+		expected := strings.Join(test.expected, "\n")
+		if s != expected {
+			t.Errorf("collapsing %v: expected\n%s\nreceived\n%s", collapsed.Val(), expected, s)
+		}
+	}
+}
+
+// checkWidth reports an error if a line of s is wider than w.
+func checkWidth(t *testing.T, s string, w int) {
+	for _, line := range strings.Split(s, "\n") {
+		if lineW := utf8.RuneCountInString(line); lineW > w {
+			t.Errorf("the line %q is %d columns wide, expected at most %d", line, lineW, w)
+		}
+	}
+}
+
+func TestRenderMaxWidthWrap(t *testing.T) {
+	tr := NewTree(NodeString("a label that is definitely too long for the width"))
+	tr.AddChild(NodeString("short"))
+	s, err := tr.Render(RenderOptions{MaxWidth: 20})
+	if err != nil {
+		t.Errorf("the tree should fit by wrapping labels: %v", err)
+	}
+	checkWidth(t, s, 20)
+	if !strings.Contains(s, "definitely") {
+		t.Errorf("wrapping should break labels at spaces")
+	}
+	fmt.Println(s)
+}
+
+func TestRenderMaxWidthOutline(t *testing.T) {
+	tr := NewTree(NodeString("root"))
+	for i := 0; i < 3; i++ {
+		tChild := tr.AddChild(NodeString(fmt.Sprintf("child %d", i)))
+		for j := 0; j < 4; j++ {
+			tChild.AddChild(NodeString(fmt.Sprintf("leaf %d.%d", i, j)))
+		}
+	}
+	s, err := tr.Render(RenderOptions{MaxWidth: 60, NoPages: true})
+	if err != nil {
+		t.Errorf("the tree should fit by switching subtrees to the outline layout: %v", err)
+	}
+	checkWidth(t, s, 60)
+	if !strings.Contains(s, "│╰── leaf 2.3│") {
+		t.Errorf("the subtrees of the children should be drawn in the outline layout")
+	}
+	if !strings.Contains(s, "│root│") {
+		t.Errorf("the root should still be drawn in a box")
+	}
+	fmt.Println(s)
+}
+
+func TestRenderMaxWidthPages(t *testing.T) {
+	tr := NewTree(NodeString("root"))
+	for i := 0; i < 2; i++ {
+		tChild := tr
+		for j := 0; j < 5; j++ {
+			tChild = tChild.AddChild(NodeString(fmt.Sprintf("nested %d", j)))
+		}
+	}
+
+	_, err := tr.Render(RenderOptions{MaxWidth: 12, NoPages: true})
+	if err == nil {
+		t.Errorf("the tree shouldn't fit without pages")
+	}
+
+	s, err := tr.Render(RenderOptions{MaxWidth: 12})
+	if err != nil {
+		t.Errorf("the tree should fit by splitting it into pages: %v", err)
+	}
+	checkWidth(t, s, 12)
+	if !strings.Contains(s, "\n\n") {
+		t.Errorf("the pages should be separated by an empty line")
+	}
+	fmt.Println(s)
+}
+
+// limitedWriter accepts n bytes and then fails.
+type limitedWriter struct {
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > l.n {
+		written := l.n
+		l.n = 0
+		return written, fmt.Errorf("limited writer is full")
+	}
+	l.n -= len(p)
+	return len(p), nil
+}
+
+func TestFprint(t *testing.T) {
+	tr := traversalTree()
+	opts := RenderOptions{MaxWidth: 12}
+	expected, err := tr.Render(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var b strings.Builder
+	// Fprint always starts from the root, like Render
+	err = Fprint(&b, tr.Children()[0], opts)
+	if err != nil || b.String() != expected {
+		t.Errorf("Fprint should write what Render returns, expected:\n%s\nreceived:\n%s", expected, b.String())
+	}
+
+	err = Fprint(&limitedWriter{n: 10}, tr, opts)
+	if err == nil {
+		t.Errorf("expected error from the writer, received nil")
+	}
+	err = Fprint(&b, tr, RenderOptions{MaxDepth: -1})
+	if err == nil {
+		t.Errorf("expected error for invalid options, received nil")
+	}
+}
+
+func TestRenderOutput(t *testing.T) {
+	tr := traversalTree()
+	padded, err := tr.Render(RenderOptions{MaxWidth: 12})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := tr.Render(RenderOptions{MaxWidth: 12, Output: drawer.OutputOptions{TrimTrailing: true, NoFinalNewline: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows := strings.Split(strings.TrimSuffix(padded, "\n"), "\n")
+	for i, row := range rows {
+		rows[i] = strings.TrimRight(row, " ")
+	}
+	// The pages are still separated by an empty line, only the last one loses its newline
+	if expected := strings.Join(rows, "\n"); s != expected {
+		t.Errorf("expected:\n%q\nreceived:\n%q", expected, s)
+	}
+}
+
+func TestCanvas(t *testing.T) {
+	tr := traversalTree()
+	d, err := tr.Canvas(RenderOptions{})
+	if err != nil {
+		t.Errorf("default options should be valid: %v", err)
+	}
+	if d.String() != tr.String() {
+		t.Errorf("the canvas drawn with default options should be equal to String")
+	}
+
+	tiles, err := d.Tiles(10, 6, 1)
+	if err != nil {
+		t.Errorf("the canvas should be split into tiles: %v", err)
+	}
+	for _, tile := range tiles {
+		fmt.Println(tile)
+	}
+}
+
+func TestLayout(t *testing.T) {
+	tr := traversalTree()
+	t2, _ := tr.Child(0)
+	t2.SetCollapsed(true)
+
+	d, boxes, err := tr.Layout(RenderOptions{})
+	if err != nil {
+		t.Errorf("default options should be valid: %v", err)
+	}
+	if d.String() != tr.String() {
+		t.Errorf("the canvas of the layout should be equal to String")
+	}
+	if len(boxes) != 5 {
+		t.Errorf("expected 5 visible nodes, received %d", len(boxes))
+	}
+
+	// The value of each node should be drawn inside its box
+	for n, box := range boxes {
+		r, err := d.Rune(box.X+1, box.Y+1)
+		if err != nil {
+			t.Errorf("the box of %v should be inside the canvas: %v", n.Val(), err)
+			continue
+		}
+		if string(r) != Label(n.Val()) {
+			t.Errorf("expected %v inside its box, received %c", n.Val(), r)
+		}
+		if r, _ := d.Rune(box.X, box.Y+box.H-1); r != '╰' {
+			t.Errorf("expected ╰ in the bottom left corner of the box of %v, received %c", n.Val(), r)
+		}
+	}
+}
+
+func TestRenderStyleAndOrientation(t *testing.T) {
+	tr := traversalTree()
+	s, err := tr.Render(RenderOptions{Style: drawer.ASCIIStyle, Orientation: BottomUp})
+	if err != nil {
+		t.Errorf("ascii style and bottom up orientation should be valid: %v", err)
+	}
+	expected := strings.Join([]string{
+		"+-+ +-+     +-+",
+		"|5| |6|     |7|",
+		"+++ +++     +++",
+		" +-+-+       | ",
+		"  +++   +-+ +++",
+		"  |2|   |3| |4|",
+		"  +++   +++ +++",
+		"   +---+-+---+ ",
+		"      +++      ",
+		"      |1|      ",
+		"      +-+      ",
+		"",
+	}, "\n")
+	if s != expected {
+		t.Errorf("expected\n%s\nreceived\n%s", expected, s)
+	}
+
+	_, boxes, err := tr.Layout(RenderOptions{Orientation: BottomUp})
+	if err != nil {
+		t.Errorf("bottom up orientation should be valid: %v", err)
+	}
+	if box := boxes[tr]; box.Y != 8 {
+		t.Errorf("the box of the root should be at the bottom, received %v", box)
+	}
+
+	_, err = tr.Render(RenderOptions{Orientation: Orientation(42)})
+	if err == nil {
+		t.Errorf("unknown orientations shouldn't be accepted")
+	}
+}
+
+func TestRenderSideways(t *testing.T) {
+	tr := NewTree(NodeString("root"))
+	a := tr.AddChild(NodeString("a"))
+	a.AddChild(NodeString("leaf\nxy"))
+	tr.AddChild(NodeString("bb"))
+
+	tests := []struct {
+		orientation Orientation
+		expected    []string
+		rootBox     drawer.Rect
+	}{
+		{LeftRight, []string{
+			"           ╭────╮",
+			"       ╭─╮ │leaf│",
+			"      ╭┤a├─┤xy  │",
+			"╭────╮│╰─╯ ╰────╯",
+			"│root├┤          ",
+			"╰────╯│          ",
+			"      │╭──╮      ",
+			"      ╰┤bb│      ",
+			"       ╰──╯      ",
+		}, drawer.Rect{X: 0, Y: 3, W: 6, H: 3}},
+		{RightLeft, []string{
+			"╭────╮           ",
+			"│leaf│ ╭─╮       ",
+			"│xy  ├─┤a├╮      ",
+			"╰────╯ ╰─╯│╭────╮",
+			"          ├┤root│",
+			"          │╰────╯",
+			"      ╭──╮│      ",
+			"      │bb├╯      ",
+			"      ╰──╯       ",
+		}, drawer.Rect{X: 11, Y: 3, W: 6, H: 3}},
+	}
+	for _, test := range tests {
+		s, err := tr.Render(RenderOptions{Orientation: test.orientation})
+		if err != nil {
+			t.Errorf("orientation %d should be valid: %v", test.orientation, err)
+			continue
+		}
+		expected := strings.Join(test.expected, "\n") + "\n"
+		if s != expected {
+			t.Errorf("expected\n%s\nreceived\n%s", expected, s)
+		}
+
+		_, boxes, err := tr.Layout(RenderOptions{Orientation: test.orientation})
+		if err != nil {
+			t.Errorf("orientation %d should be valid: %v", test.orientation, err)
+			continue
+		}
+		if boxes[tr] != test.rootBox {
+			t.Errorf("expected the box of the root in %v, received %v", test.rootBox, boxes[tr])
+		}
+		if box := boxes[a]; box != (drawer.Rect{X: 7, Y: 1, W: 3, H: 3}) {
+			t.Errorf("expected the box of a in {7 1 3 3}, received %v", box)
+		}
+	}
+}
+
+func TestRenderRankAligned(t *testing.T) {
+	tr := NewTree(NodeString("root"))
+	a := tr.AddChild(NodeString("a\ntall\nnode"))
+	a.AddChild(NodeString("a1"))
+	b := tr.AddChild(NodeString("b"))
+	b1 := b.AddChild(NodeString("b1"))
+	b.AddChild(NodeString("b2"))
+	x := b1.AddChild(NodeString("x"))
+
+	tests := []struct {
+		opts     RenderOptions
+		expected []string
+	}{
+		{RenderOptions{RankAligned: true}, []string{
+			"      ╭────╮       ",
+			"      │root│       ",
+			"      ╰──┬─╯       ",
+			"   ╭─────┴───╮     ",
+			"╭──┴─╮      ╭┴╮    ",
+			"│a   │      │b│    ",
+			"│tall│      ╰┬╯    ",
+			"│node│       │     ",
+			"╰──┬─╯       │     ",
+			"   │      ╭──┴──╮  ",
+			" ╭─┴╮   ╭─┴╮  ╭─┴╮ ",
+			" │a1│   │b1│  │b2│ ",
+			" ╰──╯   ╰─┬╯  ╰──╯ ",
+			"          │        ",
+			"         ╭┴╮       ",
+			"         │x│       ",
+			"         ╰─╯       ",
+		}},
+		{RenderOptions{RankAligned: true, Orientation: LeftRight}, []string{
+			"       ╭────╮         ",
+			"       │a   │ ╭──╮    ",
+			"      ╭┤tall├─┤a1│    ",
+			"      ││node│ ╰──╯    ",
+			"      │╰────╯         ",
+			"╭────╮│               ",
+			"│root├┤       ╭──╮ ╭─╮",
+			"╰────╯│      ╭┤b1├─┤x│",
+			"      │╭─╮   │╰──╯ ╰─╯",
+			"      ╰┤b├───┤        ",
+			"       ╰─╯   │╭──╮    ",
+			"             ╰┤b2│    ",
+			"              ╰──╯    ",
+		}},
+		{RenderOptions{RankAligned: true, Highlight: HighlightNodes(x)}, []string{
+			"      ╭────╮       ",
+			"      │root│       ",
+			"      ╰──┰─╯       ",
+			"   ╭─────┺━━━┓     ",
+			"╭──┴─╮      ╭┸╮    ",
+			"│a   │      │b│    ",
+			"│tall│      ╰┰╯    ",
+			"│node│       ┃     ",
+			"╰──┬─╯       ┃     ",
+			"   │      ┏━━┹──╮  ",
+			" ╭─┴╮   ╭─┸╮  ╭─┴╮ ",
+			" │a1│   │b1│  │b2│ ",
+			" ╰──╯   ╰─┰╯  ╰──╯ ",
+			"          ┃        ",
+			"         ┏┻┓       ",
+			"         ┃x┃       ",
+			"         ┗━┛       ",
+		}},
+	}
+	for _, test := range tests {
+		s, err := tr.Render(test.opts)
+		if err != nil {
+			t.Errorf("rendering with %+v should succeed: %v", test.opts, err)
+			continue
+		}
+		expected := strings.Join(test.expected, "\n") + "\n"
+		if s != expected {
+			t.Errorf("expected\n%s\nreceived\n%s", expected, s)
+		}
+	}
+
+	_, boxes, err := tr.Layout(RenderOptions{RankAligned: true})
+	if err != nil {
+		t.Fatalf("rendering should succeed: %v", err)
+	}
+	if boxes[a].Y != boxes[b].Y || boxes[a.Children()[0]].Y != boxes[b1].Y {
+		t.Errorf("nodes at the same depth should start on the same row, received %v %v and %v %v",
+			boxes[a], boxes[b], boxes[a.Children()[0]], boxes[b1])
+	}
+
+	s, _ := tr.Render(RenderOptions{RankAligned: true})
+	parsed, err := ParseDrawing(s)
+	if err != nil {
+		t.Fatalf("the rank-aligned drawing should be parsed: %v", err)
+	}
+	if parsed.String() != tr.String() {
+		t.Errorf("the rank-aligned drawing should be read back as the same tree, received\n%s", parsed)
+	}
+}
+
+func TestOutline(t *testing.T) {
+	tr := traversalTree()
+	s, err := tr.Outline(RenderOptions{MaxChildren: 2})
+	if err != nil {
+		t.Errorf("positive options should be valid: %v", err)
+	}
+	expected := strings.Join([]string{
+		"1",
+		"├── 2",
+		"│   ├── 5",
+		"│   ╰── 6",
+		"├── 3",
+		"╰── … (+2 nodes)",
+		"",
+	}, "\n")
+	if s != expected {
+		t.Errorf("expected\n%s\nreceived\n%s", expected, s)
+	}
+}
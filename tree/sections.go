@@ -0,0 +1,46 @@
+package tree
+
+import (
+	"strings"
+
+	"github.com/m1gwings/treedrawer/drawer"
+)
+
+// NodeSections is a value drawn in a box divided into sections by horizontal lines, like
+//
+//	╭──────────╮
+//	│Point     │
+//	├──────────┤
+//	│x, y int  │
+//	├──────────┤
+//	│String()  │
+//	╰──────────╯
+//
+// The first section is the title of the box, each section can span several lines.
+type NodeSections []string
+
+// Draw satisfies the NodeValue interface.
+// Sections are drawn one below the other, with an empty row between them where the renderer draws the dividers.
+func (s NodeSections) Draw() *drawer.Drawer {
+	return NodeString(s.text()).Draw()
+}
+
+// text returns the text drawn by s, with an empty line where each divider goes.
+func (s NodeSections) text() string {
+	return strings.Join(s, "\n\n")
+}
+
+// dividers returns the rows of the drawer returned by Draw which divide the sections.
+func (s NodeSections) dividers() []int {
+	if len(s) == 0 {
+		return nil
+	}
+	dividers := make([]int, len(s)-1)
+	y := 0
+	for i, section := range s[:len(s)-1] {
+		y += strings.Count(section, "\n") + 1
+		dividers[i] = y
+		y++
+	}
+	return dividers
+}
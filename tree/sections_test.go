@@ -0,0 +1,154 @@
+package tree
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/m1gwings/treedrawer/drawer"
+)
+
+// classTree returns a tree of classes drawn in boxes divided into sections.
+func classTree() *Tree {
+	tr := NewTree(NodeSections{"Shape", "area() float64"})
+	tr.AddChild(NodeSections{"Circle", "r float64", "area() float64"})
+	tr.AddChild(NodeString("Point"))
+	return tr
+}
+
+func TestNodeSectionsDividers(t *testing.T) {
+	tests := []struct {
+		s        NodeSections
+		label    string
+		dividers []int
+	}{
+		{NodeSections{"title"}, "title", []int{}},
+		{NodeSections{"title", "a\nb", "c"}, "title\n\na\nb\n\nc", []int{1, 4}},
+		{NodeSections{"", ""}, "\n\n", []int{1}},
+	}
+	for _, test := range tests {
+		if label := Label(test.s); label != test.label {
+			t.Errorf("the label of %q should be %q, got %q", test.s, test.label, label)
+		}
+		if dividers := test.s.dividers(); !reflect.DeepEqual(dividers, test.dividers) {
+			t.Errorf("the dividers of %q should be %v, got %v", test.s, test.dividers, dividers)
+		}
+	}
+}
+
+func TestRenderSections(t *testing.T) {
+	tests := []struct {
+		opts     RenderOptions
+		expected []string
+	}{
+		{RenderOptions{}, []string{
+			"    ╭──────────────╮     ",
+			"    │Shape         │     ",
+			"    ├──────────────┤     ",
+			"    │area() float64│     ",
+			"    ╰───────┬──────╯     ",
+			"        ╭───┴────────╮   ",
+			"╭───────┴──────╮  ╭──┴──╮",
+			"│Circle        │  │Point│",
+			"├──────────────┤  ╰─────╯",
+			"│r float64     │         ",
+			"├──────────────┤         ",
+			"│area() float64│         ",
+			"╰──────────────╯         ",
+		}},
+		{RenderOptions{Orientation: BottomUp}, []string{
+			"╭──────────────╮         ",
+			"│Circle        │         ",
+			"├──────────────┤         ",
+			"│r float64     │         ",
+			"├──────────────┤  ╭─────╮",
+			"│area() float64│  │Point│",
+			"╰───────┬──────╯  ╰──┬──╯",
+			"        ╰───┬────────╯   ",
+			"    ╭───────┴──────╮     ",
+			"    │Shape         │     ",
+			"    ├──────────────┤     ",
+			"    │area() float64│     ",
+			"    ╰──────────────╯     ",
+		}},
+		{RenderOptions{Orientation: LeftRight, Style: drawer.DoubleStyle}, []string{
+			"                 ╔══════════════╗",
+			"                 ║Circle        ║",
+			"                 ╠══════════════╣",
+			"╔══════════════╗╔╣r float64     ║",
+			"║Shape         ║║╠══════════════╣",
+			"╠══════════════╬╣║area() float64║",
+			"║area() float64║║╚══════════════╝",
+			"╚══════════════╝║                ",
+			"                ║╔═════╗         ",
+			"                ╚╣Point║         ",
+			"                 ╚═════╝         ",
+		}},
+	}
+	tr := classTree()
+	for _, test := range tests {
+		s, err := tr.Render(test.opts)
+		if err != nil {
+			t.Errorf("rendering with %+v should succeed: %v", test.opts, err)
+			continue
+		}
+		if expected := strings.Join(test.expected, "\n") + "\n"; s != expected {
+			t.Errorf("rendering with %+v, expected\n%s\ngot\n%s", test.opts, expected, s)
+		}
+	}
+}
+
+func TestRenderSectionsWrapped(t *testing.T) {
+	tr := NewTree(NodeSections{"Shape", "area of the shape"})
+	s, err := tr.Render(RenderOptions{MaxWidth: 10})
+	if err != nil {
+		t.Fatalf("rendering should succeed: %v", err)
+	}
+	expected := strings.Join([]string{
+		"╭───────╮",
+		"│Shape  │",
+		"├───────┤",
+		"│area of│",
+		"│the    │",
+		"│shape  │",
+		"╰───────╯",
+	}, "\n") + "\n"
+	if s != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, s)
+	}
+}
+
+func TestAddBoxAroundDividersOutside(t *testing.T) {
+	d, _ := drawer.NewDrawer(5, 5)
+	if err := addBoxAround(d, drawer.RoundedStyle, 0, 0, 4, 4, []int{4}, nil); err == nil {
+		t.Errorf("a divider on the border of the box should be rejected")
+	}
+	if err := addBoxAround(d, drawer.RoundedStyle, 0, 0, 4, 4, nil, []int{0}); err == nil {
+		t.Errorf("a divider on the border of the box should be rejected")
+	}
+}
+
+func TestParseDrawingSections(t *testing.T) {
+	tr := classTree()
+	parsed, err := ParseDrawing(tr.String())
+	if err != nil {
+		t.Fatalf("the drawing should be parsed: %v", err)
+	}
+	if !reflect.DeepEqual(parsed.Val(), tr.Val()) {
+		t.Errorf("the root should be %#v, got %#v", tr.Val(), parsed.Val())
+	}
+	if parsed.String() != tr.String() {
+		t.Errorf("the parsed tree should be drawn like the original one, expected\n%s\ngot\n%s", tr, parsed)
+	}
+
+	broken := strings.Join([]string{
+		"╭─────╮",
+		"│title│",
+		"├──x──┤",
+		"│body │",
+		"╰─────╯",
+	}, "\n")
+	if _, err := ParseDrawing(broken); err == nil || !strings.Contains(err.Error(), "line 3, column 4") {
+		t.Errorf("the broken divider should be reported at line 3, column 4, got %v", err)
+	}
+}
@@ -10,57 +10,168 @@ import (
 )
 
 // stringify takes a pointer to a node and draws all the tree below in a drawer.
-// Returns the drawn drawer.
+// depth is the number of edges between t and the node from which the rendering started.
+// Returns the drawn drawer and the column of the top edge of the box of t where it is linked to its parent.
 // This function is called recursively
-func stringify(t *Tree) *drawer.Drawer {
-	// Getting drawer and dimensions of this NodeValue
-	dVal := t.val.Draw()
+func (r *renderer) stringify(t *Tree, depth int) (*drawer.Drawer, int) {
+	// Getting drawer of this NodeValue, with room for its annotation
+	val := r.annotatedVal(t, depth)
+	// Moving the children down to the row of the other nodes at their depth
+	if r.ranks != nil {
+		valW, valH := val.d.Dimens()
+		if r.opts.Orientation.transposed() {
+			valH = valW
+		}
+		val.drop = r.ranks[depth] - valH
+	}
+
+	// Getting the children to draw according to the render options
+	// and recursively calling stringify for each of them
+	children := r.children(t, depth)
+	dChildren := make([]*drawer.Drawer, len(children))
+	tops := make([]int, len(children))
+	for i, tChild := range children {
+		dChildren[i], tops[i] = r.stringify(tChild, depth+1)
+	}
+
+	align := r.parentAlign(t)
+	d, p := r.compose(val, dChildren, tops, align)
+	if !r.outline {
+		r.annotate(d, t, p)
+		r.emphasize(d, t, children, p, nil)
+		r.place(t, children, p)
+		return d, p.top()
+	}
+
+	// Switching the widest children to the outline layout until the drawer fits
+	outlined := make([]bool, len(children))
+	for !r.fits(d) {
+		widest, widestW := -1, 0
+		for i, dChild := range dChildren {
+			dChildW, _ := dChild.Dimens()
+			if !outlined[i] && dChildW > widestW {
+				widest, widestW = i, dChildW
+			}
+		}
+		// When every child is already in the outline layout, the whole subtree is switched
+		if widest == -1 {
+			d, top := r.boxedOutline(t, depth)
+			r.placeOutline(t, d)
+			return d, top
+		}
+		dChildren[widest], tops[widest] = r.boxedOutline(children[widest], depth+1)
+		r.placeOutline(children[widest], dChildren[widest])
+		outlined[widest] = true
+		d, p = r.compose(val, dChildren, tops, align)
+	}
+	r.annotate(d, t, p)
+	r.emphasize(d, t, children, p, outlined)
+	r.place(t, children, p)
+	return d, p.top()
+}
+
+// placement describes where compose placed the box of a node and the drawers of its children.
+// link is the column of the ┬ under the box and links are the columns of the ┴ above each child,
+// link is -1 when there are no children.
+type placement struct {
+	box      drawer.Rect
+	children []drawer.Rect
+	link     int
+	links    []int
+}
+
+// top returns the column of the top edge of the box where it is linked to the parent, which is in its middle.
+func (p placement) top() int {
+	return p.box.X + p.box.W/2
+}
+
+// boxed is the drawer of a value together with what compose needs to draw the box around it.
+type boxed struct {
+	d *drawer.Drawer
+	// margin is the number of columns left free on both sides of the box, which stays centered
+	margin int
+	// dividers are the rows of d on which the box is divided into sections
+	dividers []int
+	// drop is the number of rows added to the connection under the box, which moves the children down
+	drop int
+}
+
+// fitBox returns the x coordinate of a box boxW columns wide with link in its middle,
+// the number of columns by which the content of a drawer w columns wide has to be shifted to the right
+// and the new width of the drawer, so that the box fits with margin columns free on both sides.
+func fitBox(link, boxW, margin, w int) (boxX, shift, newW int) {
+	boxX = link - boxW/2
+	if boxX < margin {
+		shift = margin - boxX
+		boxX, w = margin, w+shift
+	}
+	if boxX+boxW+margin > w {
+		w = boxX + boxW + margin
+	}
+	return boxX, shift, w
+}
+
+// compose draws the drawer of the value val of a node inside a box, above the drawers of its children,
+// and connects them with pipes.
+// tops are the columns of the top edge of the box of each child where it is linked to the parent,
+// relative to the drawer of the child, and align is where the box goes with respect to the children.
+// Returns the drawn drawer and the placement of the box and of the children.
+func (r *renderer) compose(val boxed, dChildren []*drawer.Drawer, tops []int, align ParentAlign) (*drawer.Drawer, placement) {
+	// Values are unoriented in advance since the whole drawing is going to be oriented,
+	// so that they end up in their original orientation
+	dVal, margin := r.opts.Orientation.unorient(val.d), val.margin
+
+	// Getting dimensions of dVal
 	dValW, dValH := dVal.Dimens()
+	// rows and columns are the dividers of the box, counted from the first row and column inside it
+	rows, columns := r.opts.Orientation.unorientDividers(val.dividers, dValH)
+	// childY is the row of the top of the children, below the box and the connection extended by val.drop
+	childY := dValH + 3 + val.drop
 
 	// No children
-	if len(t.Children()) == 0 {
+	if len(dChildren) == 0 {
 		// Allocating new drawer to return
 		// Ensuring that width is odd
-		d, err := drawer.NewDrawer(dValW+2+1-dValW%2, dValH+2)
+		d, err := drawer.NewDrawer(dValW+2+2*margin+1-dValW%2, dValH+2)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while allocating new drawer with no children: %v", err))
 		}
 
 		// Drawing dVal drawer onto the drawer to return
-		err = d.DrawDrawer(dVal, 1, 1)
+		err = d.DrawDrawer(dVal, margin+1, 1)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while drawing val with no children: %v", err))
 		}
 
 		// Adding a box in the drawer to return, around where the dVal drawer has been drawn
-		err = addBoxAround(d, 0, 0, dValW+1, dValH+1)
+		err = addBoxAround(d, r.style, margin, 0, margin+dValW+1, dValH+1, shift(rows, 1), shift(columns, margin+1))
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while adding box with no children: %v", err))
 		}
-		return d
+		return d, placement{box: drawer.Rect{X: margin, Y: 0, W: dValW + 2, H: dValH + 2}, link: -1}
 	}
 
 	// One child
-	if len(t.Children()) == 1 {
+	if len(dChildren) == 1 {
 		// Drawer of the child
-		var dChild *drawer.Drawer
-
-		// Recursively calling stringify of the child and initializing dChild drawer
-		tChild, err := t.Child(0)
-		if err != nil {
-			log.Fatal(fmt.Errorf("error while getting child 0 with one child: %v", err))
-		}
-		dChild = stringify(tChild)
+		dChild := dChildren[0]
 		// Getting dimensions of dChild drawer
 		dChildW, dChildH := dChild.Dimens()
 
 		// w and h represent respectively width and height of the drawer to return
-		// w is the max between the width of dVal + 2 (considering the box) and the width of the one child
-		// h is equal to the height of dVal + 2 (considering the box) + 1 (considering the "pipe") + the height of dChild
-		w := int(math.Max(float64(dValW+2), float64(dChildW)))
+		// w is the max between the width of dVal + 2 (considering the box) plus the margins and the width of the one child
+		// h is equal to the height of dVal + 2 (considering the box) + 1 (considering the "pipe") + val.drop + the height of dChild
+		w := int(math.Max(float64(dValW+2+2*margin), float64(dChildW)))
 		// Ensuring that w is odd
 		w += 1 - w%2
-		h := dValH + 3 + dChildH
+		h := childY + dChildH
+
+		// childX is the x coordinate of dChild, which is put in the middle,
+		// the pipe goes straight down to the top of its box whatever the alignment, so the box is above it
+		childX := (w - dChildW) / 2
+		link := childX + tops[0]
+		boxX, shifted, w := fitBox(link, dValW+2, margin, w)
+		childX, link = childX+shifted, link+shifted
 
 		// Allocating new drawer to return
 		d, err := drawer.NewDrawer(w, h)
@@ -68,64 +179,67 @@ func stringify(t *Tree) *drawer.Drawer {
 			log.Fatal(fmt.Errorf("error while allocating new drawer with one child: %v", err))
 		}
 
-		// Drawing dVal onto the drawer to return with x in (w-dValW)/2 to put dVal in the middle
+		// Drawing dVal onto the drawer to return with x just inside the box
 		// (remember that drawer.DrawDrawer takes coordinates of top left corner)
 		// and y in 1 (considering the box)
-		err = d.DrawDrawer(dVal, (w-dValW)/2, 1)
+		err = d.DrawDrawer(dVal, boxX+1, 1)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while drawing val with one child: %v", err))
 		}
 
 		// Adding a box in the drawer to return, around where the dVal drawer has been drawn
-		// start coordinates are taken considering d.DrawDrawer above - 1 in order to not overwrite
 		// end coordinates are just start coordinates plus respectively dValW+1 and dValH+1 in order to not overwrite
-		err = addBoxAround(d, (w-dValW)/2-1, 0, (w-dValW)/2+dValW, dValH+1)
+		err = addBoxAround(d, r.style, boxX, 0, boxX+dValW+1, dValH+1, shift(rows, 1), shift(columns, boxX+1))
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while adding box with one child: %v", err))
 		}
 
-		// Drawing the upper-link onto the drawer to return with x in the middle
-		// and y just above the pipe
-		err = d.DrawRune('┬', w/2, dValH+1)
+		// Drawing the upper-link onto the drawer to return with x in the middle of the box
+		// and y just above the pipe, merged with the border where a divider of the box ends
+		err = d.DrawRuneMode(r.style.TeeDown, link, dValH+1, drawer.MergeLines)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while drawing ┬ with one child: %v", err))
 		}
 
-		// Drawing the pipe onto the drawer to return with x in the middle
-		// and y in dValH + 2 (considering the box)
-		err = d.DrawRune('│', w/2, dValH+2)
-		if err != nil {
-			log.Fatal(fmt.Errorf("error while drawing | with one child: %v", err))
+		// Drawing the pipe onto the drawer to return with x in the middle of the box
+		// and y from dValH + 2 (considering the box) down to the child
+		for y := dValH + 2; y < childY; y++ {
+			err = d.DrawRune(r.style.Vertical, link, y)
+			if err != nil {
+				log.Fatal(fmt.Errorf("error while drawing | with one child: %v", err))
+			}
 		}
 
-		// Drawing dChild onto the drawer to return with x in (w-dChildW)/2 to put dChild in the middle
-		// (remember that drawer.DrawDrawer takes coordinates of top left corner)
-		// and y in dValH + 3 (considering the box and pipe)
-		err = d.DrawDrawer(dChild, (w-dChildW)/2, dValH+3)
+		// Drawing dChild onto the drawer to return with x in childX
+		// and y in childY (considering the box and pipe)
+		err = d.DrawDrawer(dChild, childX, childY)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while drawing child drawer with one child: %v", err))
 		}
 
-		// Drawing the lower-link onto the drawer to return with x in the middle
+		// Drawing the lower-link onto the drawer to return with x in the middle of the box
 		// and y just below the pipe
-		// this drawing must be the latest because it has to overwrite dChild
-		err = d.DrawRune('┴', w/2, dValH+3)
+		// this drawing must be the latest because it has to be merged with the border of dChild
+		err = d.DrawRuneMode(r.style.TeeUp, link, childY, drawer.MergeLines)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while drawing ┴ with one child: %v", err))
 		}
 
-		return d
+		return d, placement{
+			box:      drawer.Rect{X: boxX, Y: 0, W: dValW + 2, H: dValH + 2},
+			children: []drawer.Rect{{X: childX, Y: childY, W: dChildW, H: dChildH}},
+			link:     link,
+			links:    []int{link},
+		}
 	}
 
 	// More children
 
 	// nChildren is the number of children of t
-	nChildren := len(t.Children())
-	// dChildren stores the result of recursively call of stringify for each child
-	dChildren := make([]*drawer.Drawer, 0, nChildren)
+	nChildren := len(dChildren)
 	// childrenLeft is a slice with the x coordinate of the upper-left corner of each child drawer to draw onto d
 	childrenLeft := make([]int, 0, nChildren)
-	// childrenMiddle is a slice with the x coordinate of the middle of each child drawer to draw onto d
+	// childrenMiddle is a slice with the x coordinate of the top of the box of each child drawer to draw onto d
 	childrenMiddle := make([]int, 0, nChildren)
 	// childrenW is the width required to draw children
 	// it is incremented child by child to obtain the x coordinate of the upper-left corner for each child
@@ -134,9 +248,7 @@ func stringify(t *Tree) *drawer.Drawer {
 	maxChildH := 0
 
 	// Iterates over children to calculate maxChildH, childrenLeft and childrenMiddle
-	for i, tChild := range t.Children() {
-		dChild := stringify(tChild)
-		dChildren = append(dChildren, dChild)
+	for i, dChild := range dChildren {
 		dChildW, dChildH := dChild.Dimens()
 		maxChildH = int(math.Max(float64(maxChildH), float64(dChildH)))
 
@@ -145,18 +257,18 @@ func stringify(t *Tree) *drawer.Drawer {
 			if (childrenW+dChildW)%2 == 1 {
 				// If final childrenW (notice that childrenW gets incremented at the end) is odd than we just have to add dChildW
 				childrenLeft = append(childrenLeft, childrenW)
-				childrenMiddle = append(childrenMiddle, childrenW+dChildW/2)
+				childrenMiddle = append(childrenMiddle, childrenW+tops[i])
 				childrenW += dChildW
 			} else {
 				// Otherwise we add one more space to make childrenW odd
 				childrenLeft = append(childrenLeft, childrenW+1)
-				childrenMiddle = append(childrenMiddle, childrenW+1+dChildW/2)
+				childrenMiddle = append(childrenMiddle, childrenW+1+tops[i])
 				childrenW += dChildW + 1
 			}
 		} else {
 			// When the child isn't the last just add it to the left of the child before with a space in between
 			childrenLeft = append(childrenLeft, childrenW)
-			childrenMiddle = append(childrenMiddle, childrenW+dChildW/2)
+			childrenMiddle = append(childrenMiddle, childrenW+tops[i])
 			childrenW += dChildW + 1
 		}
 	}
@@ -171,19 +283,35 @@ func stringify(t *Tree) *drawer.Drawer {
 		log.Fatal(fmt.Errorf("childrenMiddle is not sorted"))
 	}
 
-	// w is the width of the final drawer and is equal to the maximum between dValW+2 and childrenW
-	var w int
-	if dValW+2 > childrenW {
-		w = dValW + 2
-		// If parent width is greater than children width, children get centered by shifting each child
-		for i := 0; i < nChildren; i++ {
-			childrenLeft[i] += (w - childrenW) / 2
-			childrenMiddle[i] += (w - childrenW) / 2
+	// w is the width of the final drawer and link is the column of the upper-link under the parent
+	var w, link int
+	switch align {
+	case ParentFirst:
+		w, link = childrenW, childrenMiddle[0]
+	case ParentMiddle:
+		w, link = childrenW, childrenMiddle[(nChildren-1)/2]
+	default:
+		// w is equal to the maximum between dValW+2 plus the margins and childrenW
+		if dValW+2+2*margin > childrenW {
+			w = dValW + 2 + 2*margin
+			// If parent width is greater than children width, children get centered by shifting each child
+			for i := 0; i < nChildren; i++ {
+				childrenLeft[i] += (w - childrenW) / 2
+				childrenMiddle[i] += (w - childrenW) / 2
+			}
+		} else {
+			w = childrenW
 		}
-	} else {
-		w = childrenW
+		// The parent goes in the middle, as long as it is above the line which joins the children
+		link = int(math.Min(math.Max(float64(w/2), float64(childrenMiddle[0])), float64(childrenMiddle[nChildren-1])))
 	}
-	h := dValH + 3 + maxChildH
+	boxX, shifted, w := fitBox(link, dValW+2, margin, w)
+	link += shifted
+	for i := 0; i < nChildren; i++ {
+		childrenLeft[i] += shifted
+		childrenMiddle[i] += shifted
+	}
+	h := childY + maxChildH
 
 	// Allocating new drawer to return
 	d, err := drawer.NewDrawer(w, h)
@@ -191,84 +319,105 @@ func stringify(t *Tree) *drawer.Drawer {
 		log.Fatal(fmt.Errorf("error while allocating new drawer with more children: %v", err))
 	}
 
-	// Drawing dVal onto the drawer to return with x in (w-dValW)/2 to put dVal in the middle
+	// Drawing dVal onto the drawer to return with x just inside the box
 	// (remember that drawer.DrawDrawer takes coordinates of top left corner)
 	// and y in 1 (considering the box)
-	err = d.DrawDrawer(dVal, (w-dValW)/2, 1)
+	err = d.DrawDrawer(dVal, boxX+1, 1)
 	if err != nil {
 		log.Fatal(fmt.Errorf("error while drawing val with more children: %v", err))
 	}
 
 	// Adding a box in the drawer to return, around where the dVal drawer has been drawn
-	// start coordinates are taken considering d.DrawDrawer above - 1 in order to not overwrite
 	// end coordinates are just start coordinates plus respectively dValW+1 and dValH+1 in order to not overwrite
-	err = addBoxAround(d, (w-dValW)/2-1, 0, (w-dValW)/2+dValW, dValH+1)
+	err = addBoxAround(d, r.style, boxX, 0, boxX+dValW+1, dValH+1, shift(rows, 1), shift(columns, boxX+1))
 	if err != nil {
 		log.Fatal(fmt.Errorf("error while adding box with more children: %v", err))
 	}
 
 	// Drawing children onto the drawer to return
 	for i := 0; i < nChildren; i++ {
-		err = d.DrawDrawer(dChildren[i], childrenLeft[i], dValH+3)
+		err = d.DrawDrawer(dChildren[i], childrenLeft[i], childY)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while drawing %d child: %v", i, err))
 		}
 	}
 
 	// Drawing upper-link ┬ under the parent
-	err = d.DrawRune('┬', w/2, dValH+1)
+	err = d.DrawRuneMode(r.style.TeeDown, link, dValH+1, drawer.MergeLines)
 	if err != nil {
 		log.Fatal(fmt.Errorf("error while drawing upper-link ┬ under the parent: %v", err))
 	}
+	// Extending the pipe under the parent down to the line which joins the children
+	for y := dValH + 2; y < childY-1; y++ {
+		err = d.DrawRune(r.style.Vertical, link, y)
+		if err != nil {
+			log.Fatal(fmt.Errorf("error while drawing | under the parent: %v", err))
+		}
+	}
 
-	// Drawing lower-link ┴ above the children
+	// Drawing lower-link ┴ above the children, merged with their borders
 	for i, x := range childrenMiddle {
-		err = d.DrawRune('┴', x, dValH+3)
+		err = d.DrawRuneMode(r.style.TeeUp, x, childY, drawer.MergeLines)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while drawing lower-link ┴ above the %dth child: %v", i, err))
 		}
 	}
 
-	// Drawing left-corner ╭ above the left most child
-	err = d.DrawRune('╭', childrenMiddle[0], dValH+2)
+	// Drawing left-corner ╭ above the left most child, or ├ when the parent is right above it
+	leftCorner := r.style.TopLeft
+	if link == childrenMiddle[0] {
+		leftCorner = r.style.TeeRight
+	}
+	err = d.DrawRune(leftCorner, childrenMiddle[0], childY-1)
 	if err != nil {
-		log.Fatal(fmt.Errorf("error while drawing left-corner ╭ above the left most child: %v", err))
+		log.Fatal(fmt.Errorf("error while drawing left-corner %c above the left most child: %v", leftCorner, err))
 	}
-	// Drawing right-corner ╮ above the right most child
-	err = d.DrawRune('╮', childrenMiddle[len(childrenMiddle)-1], dValH+2)
+	// Drawing right-corner ╮ above the right most child, or ┤ when the parent is right above it
+	rightCorner := r.style.TopRight
+	if link == childrenMiddle[nChildren-1] {
+		rightCorner = r.style.TeeLeft
+	}
+	err = d.DrawRune(rightCorner, childrenMiddle[nChildren-1], childY-1)
 	if err != nil {
-		log.Fatal(fmt.Errorf("error while drawing right-corner ╮ above the right most child: %v", err))
+		log.Fatal(fmt.Errorf("error while drawing right-corner %c above the right most child: %v", rightCorner, err))
 	}
 
 	// Finish to connect the pipe
-	for x := childrenMiddle[0] + 1; x < childrenMiddle[len(childrenMiddle)-1]; x++ {
-		underParent := x == w/2
+	for x := childrenMiddle[0] + 1; x < childrenMiddle[nChildren-1]; x++ {
+		underParent := x == link
 		shouldBeAt := sort.SearchInts(childrenMiddle, x)
 		aboveChild := shouldBeAt < len(childrenMiddle) && childrenMiddle[shouldBeAt] == x
 		var connection rune
 		switch {
 		case underParent && aboveChild:
-			connection = '┼'
+			connection = r.style.Cross
 		case underParent:
-			connection = '┴'
+			connection = r.style.TeeUp
 		case aboveChild:
-			connection = '┬'
+			connection = r.style.TeeDown
 		default:
-			connection = '─'
+			connection = r.style.Horizontal
 		}
-		err = d.DrawRune(connection, x, dValH+2)
+		err = d.DrawRune(connection, x, childY-1)
 		if err != nil {
 			log.Fatal(fmt.Errorf("error while drawing %c at position %d to finish connection: %v", connection, x, err))
 		}
 	}
 
-	return d
+	p := placement{box: drawer.Rect{X: boxX, Y: 0, W: dValW + 2, H: dValH + 2}, link: link, links: childrenMiddle}
+	for i, dChild := range dChildren {
+		dChildW, dChildH := dChild.Dimens()
+		p.children = append(p.children, drawer.Rect{X: childrenLeft[i], Y: childY, W: dChildW, H: dChildH})
+	}
+	return d, p
 }
 
-// addBoxAround draws a box onto d
+// addBoxAround draws a box onto d with the runes of style
 // the box starts at startX and startY coordinates
 // and ends at endX and endY
-func addBoxAround(d *drawer.Drawer, startX, startY, endX, endY int) error {
+// rows and columns are the coordinates of the horizontal and vertical dividers inside the box,
+// which are joined to the border with tees
+func addBoxAround(d *drawer.Drawer, style drawer.Style, startX, startY, endX, endY int, rows, columns []int) error {
 	// Checking that start and end coordinates are valid
 	if startX < 0 || startY < 0 || endX < 0 || endY < 0 {
 		return fmt.Errorf("can't draw on negative coordinates %d %d %d %d", startX, startY, endX, endY)
@@ -280,21 +429,31 @@ func addBoxAround(d *drawer.Drawer, startX, startY, endX, endY int) error {
 	if endX >= dW || endY >= dH {
 		return fmt.Errorf("end overflows the drawer with dimes %d %d, %d %d %d %d", dW, dH, startX, startY, endX, endY)
 	}
+	for _, y := range rows {
+		if y <= startY || y >= endY {
+			return fmt.Errorf("divider at row %d outside the box %d %d %d %d", y, startX, startY, endX, endY)
+		}
+	}
+	for _, x := range columns {
+		if x <= startX || x >= endX {
+			return fmt.Errorf("divider at column %d outside the box %d %d %d %d", x, startX, startY, endX, endY)
+		}
+	}
 
 	// Drawing corners
-	err := d.DrawRune('╭', startX, startY)
+	err := d.DrawRune(style.TopLeft, startX, startY)
 	if err != nil {
 		return fmt.Errorf("error while drawing ╭: %v", err)
 	}
-	err = d.DrawRune('╮', endX, startY)
+	err = d.DrawRune(style.TopRight, endX, startY)
 	if err != nil {
 		return fmt.Errorf("error while drawing ╮: %v", err)
 	}
-	err = d.DrawRune('╰', startX, endY)
+	err = d.DrawRune(style.BottomLeft, startX, endY)
 	if err != nil {
 		return fmt.Errorf("error while drawing ╰: %v", err)
 	}
-	err = d.DrawRune('╯', endX, endY)
+	err = d.DrawRune(style.BottomRight, endX, endY)
 	if err != nil {
 		return fmt.Errorf("error while drawing ╯: %v", err)
 	}
@@ -302,7 +461,7 @@ func addBoxAround(d *drawer.Drawer, startX, startY, endX, endY int) error {
 	// Drawing edges
 	for x := startX + 1; x < endX; x++ {
 		for yMul := 0; yMul <= 1; yMul++ {
-			err = d.DrawRune('─', x, yMul*(endY-startY)+startY)
+			err = d.DrawRune(style.Horizontal, x, yMul*(endY-startY)+startY)
 			if err != nil {
 				return fmt.Errorf("error while drawing ─: %v", err)
 			}
@@ -310,11 +469,55 @@ func addBoxAround(d *drawer.Drawer, startX, startY, endX, endY int) error {
 	}
 	for y := startY + 1; y < endY; y++ {
 		for xMul := 0; xMul <= 1; xMul++ {
-			err = d.DrawRune('│', xMul*(endX-startX)+startX, y)
+			err = d.DrawRune(style.Vertical, xMul*(endX-startX)+startX, y)
+			if err != nil {
+				return fmt.Errorf("error while drawing │: %v", err)
+			}
+		}
+	}
+
+	// Drawing dividers
+	for _, y := range rows {
+		err = d.DrawRune(style.TeeRight, startX, y)
+		if err != nil {
+			return fmt.Errorf("error while drawing ├: %v", err)
+		}
+		for x := startX + 1; x < endX; x++ {
+			err = d.DrawRune(style.Horizontal, x, y)
+			if err != nil {
+				return fmt.Errorf("error while drawing ─: %v", err)
+			}
+		}
+		err = d.DrawRune(style.TeeLeft, endX, y)
+		if err != nil {
+			return fmt.Errorf("error while drawing ┤: %v", err)
+		}
+	}
+	for _, x := range columns {
+		err = d.DrawRune(style.TeeDown, x, startY)
+		if err != nil {
+			return fmt.Errorf("error while drawing ┬: %v", err)
+		}
+		for y := startY + 1; y < endY; y++ {
+			// A vertical divider crosses the horizontal ones
+			err = d.DrawRuneMode(style.Vertical, x, y, drawer.MergeLines)
 			if err != nil {
 				return fmt.Errorf("error while drawing │: %v", err)
 			}
 		}
+		err = d.DrawRune(style.TeeUp, x, endY)
+		if err != nil {
+			return fmt.Errorf("error while drawing ┴: %v", err)
+		}
 	}
 	return nil
 }
+
+// shift returns the coordinates in coords increased by offset.
+func shift(coords []int, offset int) []int {
+	shifted := make([]int, len(coords))
+	for i, c := range coords {
+		shifted[i] = c + offset
+	}
+	return shifted
+}
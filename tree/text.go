@@ -0,0 +1,141 @@
+package tree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+)
+
+// FromIndented reads a tree from r, written with a node on each line
+// and the children of a node in the following lines, indented more than their parent.
+// Each node is a NodeString holding its line without the surrounding spaces.
+// Spaces count as one column of indentation and tabs move to the next multiple of 8 columns,
+// like on a terminal, so that spaces and tabs can be mixed; empty lines are ignored.
+// Returns an error if the indentation doesn't describe a single tree.
+func FromIndented(r io.Reader) (*Tree, error) {
+	var root *Tree
+	// stack holds the nodes on the path from the root to the last read node, with their indentation
+	type indented struct {
+		t      *Tree
+		indent int
+	}
+	var stack []indented
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRightFunc(scanner.Text(), unicode.IsSpace)
+		label := strings.TrimLeft(line, " \t")
+		if label == "" {
+			continue
+		}
+		indent := indentation(line[:len(line)-len(label)])
+
+		if root == nil {
+			root = NewTree(NodeString(label))
+			stack = append(stack, indented{root, indent})
+			continue
+		}
+		// Going back to the parent of the node, which is the last one less indented than it
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			return nil, fmt.Errorf("line %d is not indented more than the root, there can be only one root", lineNumber)
+		}
+		stack = append(stack, indented{stack[len(stack)-1].t.AddChild(NodeString(label)), indent})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading indented tree: %v", err)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("there is no node to read")
+	}
+	return root, nil
+}
+
+// tabWidth is the number of columns between tab stops in indented trees.
+const tabWidth = 8
+
+// indentation returns the number of columns taken by prefix, made of spaces and tabs.
+func indentation(prefix string) int {
+	columns := 0
+	for _, r := range prefix {
+		if r == '\t' {
+			columns += tabWidth - columns%tabWidth
+			continue
+		}
+		columns++
+	}
+	return columns
+}
+
+// FromBrackets reads a tree from r, written in bracket notation:
+// each node is written as its label followed by its children, all enclosed in curly brackets,
+// like {a{b{c}{d}}{e}} for the tree with root a, children b and e and grandchildren c and d.
+// Each node is a NodeString holding its label, curly brackets and backslashes inside labels must be escaped with a backslash,
+// spaces between nodes and around labels are ignored.
+// Returns an error with the position, counted in runes from 0, of the first unbalanced bracket
+// or of the rune which doesn't belong to a label, or if the brackets don't describe a single tree.
+func FromBrackets(r io.Reader) (*Tree, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading bracket notation: %v", err)
+	}
+	s := []rune(string(b))
+
+	var root *Tree
+	var stack []*Tree
+	// label collects the label of the node on top of the stack, until its first child or its end
+	var label []rune
+	inLabel := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			if inLabel {
+				stack[len(stack)-1].SetVal(NodeString(strings.TrimSpace(string(label))))
+			}
+			switch {
+			case len(stack) > 0:
+				stack = append(stack, stack[len(stack)-1].AddChild(NodeString("")))
+			case root == nil:
+				root = NewTree(NodeString(""))
+				stack = append(stack, root)
+			default:
+				return nil, fmt.Errorf("unexpected { at position %d after the end of the root", i)
+			}
+			label, inLabel = label[:0], true
+		case '}':
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("unexpected } at position %d without a matching {", i)
+			}
+			if inLabel {
+				stack[len(stack)-1].SetVal(NodeString(strings.TrimSpace(string(label))))
+			}
+			stack, inLabel = stack[:len(stack)-1], false
+		default:
+			if !inLabel {
+				if unicode.IsSpace(s[i]) {
+					continue
+				}
+				return nil, fmt.Errorf("unexpected %c at position %d outside a label", s[i], i)
+			}
+			if s[i] == '\\' {
+				if i+1 == len(s) {
+					return nil, fmt.Errorf("unexpected end after \\ at position %d", i)
+				}
+				i++
+			}
+			label = append(label, s[i])
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("unexpected end with %d unclosed {", len(stack))
+	}
+	if root == nil {
+		return nil, fmt.Errorf("there is no node to read")
+	}
+	return root, nil
+}
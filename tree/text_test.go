@@ -0,0 +1,107 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFromIndented(t *testing.T) {
+	tests := []struct {
+		name, text, expected string
+	}{
+		{"spaces", "root\n  a\n    a1\n\n    a2\n  b\n", "root\n├── a\n│   ├── a1\n│   ╰── a2\n╰── b\n"},
+		{"tabs", "root\n\ta\n\t\ta1\n\tb\n", "root\n├── a\n│   ╰── a1\n╰── b\n"},
+		{"surrounding spaces", "  root  \n      a b \r\n", "root\n╰── a b\n"},
+		// A tab moves to column 8, deeper than the two spaces of a
+		{"tab below spaces", "root\n  a\n\ta1\n  b\n", "root\n├── a\n│   ╰── a1\n╰── b\n"},
+		// Eight spaces and a tab are the same indentation, so a and b are siblings
+		{"tab and spaces siblings", "root\n        a\n\tb\n  \tc\n", "root\n├── a\n├── b\n╰── c\n"},
+		// Going back to a column between two ancestors makes a child of the less indented one
+		{"uneven dedent", "root\n    a\n        a1\n  b\n", "root\n├── a\n│   ╰── a1\n╰── b\n"},
+	}
+	for _, test := range tests {
+		tr, err := FromIndented(strings.NewReader(test.text))
+		if err != nil {
+			t.Errorf("%s: the text should describe a tree: %v", test.name, err)
+			continue
+		}
+		checkParents(t, tr)
+		s, err := tr.Outline(RenderOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s != test.expected {
+			t.Errorf("%s: expected:\n%s\nreceived:\n%s", test.name, test.expected, s)
+		}
+	}
+}
+
+func TestFromIndentedErrors(t *testing.T) {
+	tests := []struct {
+		text, expected string
+	}{
+		{"", "there is no node to read"},
+		{"  \n\t\n\n", "there is no node to read"},
+		{"root\nanother root", "line 2"},
+		{"  root\n  a\n", "line 2"},
+		{"\troot\n        a\n", "line 2"},
+		{"root\n  a\n\nb\n", "line 4"},
+	}
+	for _, test := range tests {
+		_, err := FromIndented(strings.NewReader(test.text))
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%q: expected an error about %s, received %v", test.text, test.expected, err)
+		}
+	}
+}
+
+func TestFromBrackets(t *testing.T) {
+	tests := []struct {
+		text, expected string
+	}{
+		{"{a}", "a\n"},
+		{`{root {a {a1}{a2}} {b \{b\}}}`, "root\n├── a\n│   ├── a1\n│   ╰── a2\n╰── b {b}\n"},
+		{" \n{ a b {c\\\\d} {} }\n", "a b\n├── c\\d\n╰── \n"},
+		{"{ünï{ö}}", "ünï\n╰── ö\n"},
+	}
+	for _, test := range tests {
+		tr, err := FromBrackets(strings.NewReader(test.text))
+		if err != nil {
+			t.Errorf("%q: the brackets should describe a tree: %v", test.text, err)
+			continue
+		}
+		checkParents(t, tr)
+		s, err := tr.Outline(RenderOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s != test.expected {
+			t.Errorf("%q: expected:\n%s\nreceived:\n%s", test.text, test.expected, s)
+		}
+	}
+}
+
+func TestFromBracketsErrors(t *testing.T) {
+	tests := []struct {
+		text, expected string
+	}{
+		{"", "there is no node to read"},
+		{" \n\t", "there is no node to read"},
+		{"{a", "1 unclosed {"},
+		{"{a{b}{c", "2 unclosed {"},
+		{"}", "} at position 0"},
+		{"a}", "a at position 0"},
+		{"{a}}", "} at position 3"},
+		{"{a{b}}}", "} at position 6"},
+		{"{a}{b}", "{ at position 3"},
+		{"{a{b}c}", "c at position 5"},
+		{"x{a}", "x at position 0"},
+		{`{a\`, "after \\ at position 2"},
+	}
+	for _, test := range tests {
+		_, err := FromBrackets(strings.NewReader(test.text))
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%q: expected an error about %s, received %v", test.text, test.expected, err)
+		}
+	}
+}
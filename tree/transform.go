@@ -0,0 +1,72 @@
+package tree
+
+// Transformations never modify the tree they are called on,
+// they build a new tree instead, whose root is the copy of the node they are called on.
+// Values are copied as they are, so values holding references share the referenced data.
+
+// copyNode returns a new node with the same value and flags of t and no parent or children.
+func copyNode(t *Tree) *Tree {
+	return &Tree{val: t.val, collapsed: t.collapsed, annotation: t.annotation, parentAlign: t.parentAlign, textAlign: t.textAlign}
+}
+
+// Clone returns a deep copy of the tree rooted at t.
+func (t *Tree) Clone() *Tree {
+	return t.Map(func(n NodeValue) NodeValue { return n })
+}
+
+// Map returns a copy of the tree rooted at t where the value of each node is replaced by f applied to it.
+func (t *Tree) Map(f func(NodeValue) NodeValue) *Tree {
+	tCopy := copyNode(t)
+	tCopy.val = f(t.val)
+	for _, tChild := range t.children {
+		tChildCopy := tChild.Map(f)
+		tChildCopy.parent = tCopy
+		tCopy.children = append(tCopy.children, tChildCopy)
+	}
+	return tCopy
+}
+
+// Filter returns a copy of the tree rooted at t with only the nodes for which keep returns true.
+// keep is called with the nodes of the original tree.
+//
+// If keepAncestors is false a node is discarded together with its subtree when keep returns false,
+// otherwise a node is discarded only if keep returns false for the node and for all of its descendants,
+// so that matching nodes are kept with the path from t to them.
+// Returns nil if the copy of t itself is discarded.
+func (t *Tree) Filter(keep func(*Tree) bool, keepAncestors bool) *Tree {
+	matches := keep(t)
+	if !matches && !keepAncestors {
+		return nil
+	}
+	tCopy := copyNode(t)
+	for _, tChild := range t.children {
+		tChildCopy := tChild.Filter(keep, keepAncestors)
+		if tChildCopy == nil {
+			continue
+		}
+		tChildCopy.parent = tCopy
+		tCopy.children = append(tCopy.children, tChildCopy)
+	}
+	if !matches && len(tCopy.children) == 0 {
+		return nil
+	}
+	return tCopy
+}
+
+// Prune returns a copy of the tree rooted at t without the nodes which are more than depth edges below t.
+// A negative depth returns nil.
+func (t *Tree) Prune(depth int) *Tree {
+	if depth < 0 {
+		return nil
+	}
+	tCopy := copyNode(t)
+	for _, tChild := range t.children {
+		tChildCopy := tChild.Prune(depth - 1)
+		if tChildCopy == nil {
+			break
+		}
+		tChildCopy.parent = tCopy
+		tCopy.children = append(tCopy.children, tChildCopy)
+	}
+	return tCopy
+}
@@ -0,0 +1,109 @@
+package tree
+
+import (
+	"fmt"
+	"testing"
+)
+
+// checkParents reports an error for each node of the tree rooted at tr whose children don't point back to it.
+func checkParents(t *testing.T, tr *Tree) {
+	tr.Walk(func(n *Tree) error {
+		for _, nChild := range n.Children() {
+			if p, ok := nChild.Parent(); !ok || p != n {
+				t.Errorf("the parent of %v should be %v", nChild.Val(), n.Val())
+			}
+		}
+		return nil
+	})
+}
+
+// preOrderValues returns the values of the tree rooted at tr in pre-order.
+func preOrderValues(tr *Tree) (values []NodeValue) {
+	tr.Walk(func(n *Tree) error {
+		values = append(values, n.Val())
+		return nil
+	})
+	return
+}
+
+func TestClone(t *testing.T) {
+	tr := traversalTree()
+	t2, _ := tr.Child(0)
+
+	tClone := t2.Clone()
+	if _, ok := tClone.Parent(); ok {
+		t.Errorf("the clone should be the root of its own tree")
+	}
+	checkParents(t, tClone)
+	if v := preOrderValues(tClone); !equalValues(v, []NodeValue{NodeInt64(2), NodeInt64(5), NodeInt64(6)}) {
+		t.Errorf("expected clone 2 5 6, received %v", v)
+	}
+
+	tClone.AddChild(NodeInt64(8))
+	if len(t2.Children()) != 2 {
+		t.Errorf("modifying the clone shouldn't modify the original tree")
+	}
+}
+
+func TestMap(t *testing.T) {
+	tr := traversalTree()
+	tMap := tr.Map(func(n NodeValue) NodeValue {
+		return NodeString(fmt.Sprintf("#%v", n))
+	})
+	checkParents(t, tMap)
+	if v := tMap.Val(); v != NodeString("#1") {
+		t.Errorf("the root should be #1, received %v", v)
+	}
+	if v := tr.Val(); v != NodeInt64(1) {
+		t.Errorf("the original root should still be 1, received %v", v)
+	}
+
+	fmt.Println(tMap)
+}
+
+func TestFilter(t *testing.T) {
+	tr := traversalTree()
+	notThree := func(n *Tree) bool { return n.Val() != NodeInt64(3) }
+	isSix := func(n *Tree) bool { return n.Val() == NodeInt64(6) }
+
+	tFilter := tr.Filter(notThree, false)
+	checkParents(t, tFilter)
+	if v := preOrderValues(tFilter); !equalValues(v, []NodeValue{NodeInt64(1), NodeInt64(2), NodeInt64(5), NodeInt64(6), NodeInt64(4), NodeInt64(7)}) {
+		t.Errorf("expected filtered tree 1 2 5 6 4 7, received %v", v)
+	}
+
+	if tr.Filter(isSix, false) != nil {
+		t.Errorf("the root should be discarded if it doesn't match without keeping ancestors")
+	}
+
+	tFilter = tr.Filter(isSix, true)
+	checkParents(t, tFilter)
+	if v := preOrderValues(tFilter); !equalValues(v, []NodeValue{NodeInt64(1), NodeInt64(2), NodeInt64(6)}) {
+		t.Errorf("expected filtered tree 1 2 6, received %v", v)
+	}
+
+	if tr.Filter(func(*Tree) bool { return false }, true) != nil {
+		t.Errorf("nothing should be kept if no node matches")
+	}
+
+	fmt.Println(tFilter)
+}
+
+func TestPrune(t *testing.T) {
+	tr := traversalTree()
+
+	tPrune := tr.Prune(1)
+	checkParents(t, tPrune)
+	if v := preOrderValues(tPrune); !equalValues(v, []NodeValue{NodeInt64(1), NodeInt64(2), NodeInt64(3), NodeInt64(4)}) {
+		t.Errorf("expected pruned tree 1 2 3 4, received %v", v)
+	}
+	if h := tr.Prune(0).Height(); h != 0 {
+		t.Errorf("pruning at depth 0 should leave only the root, received height %d", h)
+	}
+	if tr.Prune(-1) != nil {
+		t.Errorf("pruning at negative depth should return nil")
+	}
+	if tr.Height() != 2 {
+		t.Errorf("pruning shouldn't modify the original tree")
+	}
+}
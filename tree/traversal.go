@@ -0,0 +1,184 @@
+package tree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WalkFunc is the type of the function called by Walk, WalkPostOrder and BFS for each visited node.
+//
+// If the function returns SkipSubtree the children of the node are not visited,
+// if it returns SkipAll the walk stops without errors.
+// Any other non-nil error stops the walk and is returned.
+type WalkFunc func(t *Tree) error
+
+// SkipSubtree is used as a return value from a WalkFunc to skip the children of the visited node.
+// It is ignored by WalkPostOrder since children have already been visited.
+var SkipSubtree = errors.New("skip this subtree")
+
+// SkipAll is used as a return value from a WalkFunc to stop the walk without errors.
+var SkipAll = errors.New("skip everything and stop the walk")
+
+// Walk visits the tree rooted at t in pre-order, calling fn for each node.
+// Returns the first error returned by fn other than SkipSubtree and SkipAll.
+func (t *Tree) Walk(fn WalkFunc) error {
+	err := walk(t, fn)
+	if err == SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walk visits the tree rooted at t in pre-order and returns SkipAll as is.
+// This function is called recursively
+func walk(t *Tree, fn WalkFunc) error {
+	err := fn(t)
+	if err == SkipSubtree {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, tChild := range t.children {
+		err = walk(tChild, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkPostOrder visits the tree rooted at t in post-order, calling fn for each node
+// after all of its children.
+// Returns the first error returned by fn other than SkipSubtree and SkipAll.
+func (t *Tree) WalkPostOrder(fn WalkFunc) error {
+	err := walkPostOrder(t, fn)
+	if err == SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walkPostOrder visits the tree rooted at t in post-order and returns SkipAll as is.
+// This function is called recursively
+func walkPostOrder(t *Tree, fn WalkFunc) error {
+	for _, tChild := range t.children {
+		err := walkPostOrder(tChild, fn)
+		if err != nil {
+			return err
+		}
+	}
+	err := fn(t)
+	if err == SkipSubtree {
+		return nil
+	}
+	return err
+}
+
+// BFS visits the tree rooted at t layer by layer, from left to right, calling fn for each node.
+// Returns the first error returned by fn other than SkipSubtree and SkipAll.
+func (t *Tree) BFS(fn WalkFunc) error {
+	queue := []*Tree{t}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		err := fn(current)
+		if err == SkipSubtree {
+			continue
+		}
+		if err == SkipAll {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		queue = append(queue, current.children...)
+	}
+	return nil
+}
+
+// Find returns the first node of the tree rooted at t, in pre-order, which satisfies pred.
+// Returns nil if there is no such node.
+func (t *Tree) Find(pred func(*Tree) bool) (found *Tree) {
+	t.Walk(func(current *Tree) error {
+		if pred(current) {
+			found = current
+			return SkipAll
+		}
+		return nil
+	})
+	return
+}
+
+// FindAll returns all the nodes of the tree rooted at t, in pre-order, which satisfy pred.
+func (t *Tree) FindAll(pred func(*Tree) bool) (found []*Tree) {
+	t.Walk(func(current *Tree) error {
+		if pred(current) {
+			found = append(found, current)
+		}
+		return nil
+	})
+	return
+}
+
+// Depth returns the number of edges between t and the root of the tree.
+func (t *Tree) Depth() (depth int) {
+	for current := t; current.parent != nil; current = current.parent {
+		depth++
+	}
+	return
+}
+
+// Height returns the number of edges of the longest path between t and a leaf below it.
+func (t *Tree) Height() (height int) {
+	for _, tChild := range t.children {
+		if childHeight := tChild.Height() + 1; childHeight > height {
+			height = childHeight
+		}
+	}
+	return
+}
+
+// Size returns the number of nodes in the tree rooted at t, t included.
+func (t *Tree) Size() (size int) {
+	t.Walk(func(*Tree) error {
+		size++
+		return nil
+	})
+	return
+}
+
+// Leaves returns the nodes without children of the tree rooted at t, from left to right.
+func (t *Tree) Leaves() []*Tree {
+	return t.FindAll(func(current *Tree) bool {
+		return len(current.children) == 0
+	})
+}
+
+// Path returns the nodes on the path from the root of the tree to t, both included.
+func (t *Tree) Path() []*Tree {
+	path := make([]*Tree, t.Depth()+1)
+	for i, current := len(path)-1, t; i >= 0; i, current = i-1, current.parent {
+		path[i] = current
+	}
+	return path
+}
+
+// LowestCommonAncestor returns the deepest node which has both a and b in its subtree.
+// Returns an error if a and b don't belong to the same tree.
+func LowestCommonAncestor(a, b *Tree) (*Tree, error) {
+	if a.Root() != b.Root() {
+		return nil, fmt.Errorf("the nodes don't belong to the same tree")
+	}
+	aDepth, bDepth := a.Depth(), b.Depth()
+	for ; aDepth > bDepth; aDepth-- {
+		a = a.parent
+	}
+	for ; bDepth > aDepth; bDepth-- {
+		b = b.parent
+	}
+	for a != b {
+		a, b = a.parent, b.parent
+	}
+	return a, nil
+}
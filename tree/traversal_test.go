@@ -0,0 +1,169 @@
+package tree
+
+import (
+	"errors"
+	"testing"
+)
+
+// traversalTree returns the tree below
+//
+//	    1
+//	 ╭──┼──╮
+//	 2  3  4
+//	╭┴╮    │
+//	5 6    7
+func traversalTree() *Tree {
+	tr := NewTree(NodeInt64(1))
+	t2 := tr.AddChild(NodeInt64(2))
+	tr.AddChild(NodeInt64(3))
+	t4 := tr.AddChild(NodeInt64(4))
+	t2.AddChild(NodeInt64(5))
+	t2.AddChild(NodeInt64(6))
+	t4.AddChild(NodeInt64(7))
+	return tr
+}
+
+// visit returns a WalkFunc which appends the value of each visited node to order
+// and returns the error in results associated with that value.
+func visit(order *[]NodeValue, results map[NodeValue]error) WalkFunc {
+	return func(t *Tree) error {
+		*order = append(*order, t.Val())
+		return results[t.Val()]
+	}
+}
+
+func equalValues(a, b []NodeValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWalk(t *testing.T) {
+	tr := traversalTree()
+	tests := []struct {
+		name    string
+		walk    func(*Tree, WalkFunc) error
+		results map[NodeValue]error
+		order   []NodeValue
+	}{
+		{"pre-order", (*Tree).Walk, nil, []NodeValue{NodeInt64(1), NodeInt64(2), NodeInt64(5), NodeInt64(6), NodeInt64(3), NodeInt64(4), NodeInt64(7)}},
+		{"pre-order skip", (*Tree).Walk, map[NodeValue]error{NodeInt64(2): SkipSubtree}, []NodeValue{NodeInt64(1), NodeInt64(2), NodeInt64(3), NodeInt64(4), NodeInt64(7)}},
+		{"pre-order stop", (*Tree).Walk, map[NodeValue]error{NodeInt64(5): SkipAll}, []NodeValue{NodeInt64(1), NodeInt64(2), NodeInt64(5)}},
+		{"post-order", (*Tree).WalkPostOrder, nil, []NodeValue{NodeInt64(5), NodeInt64(6), NodeInt64(2), NodeInt64(3), NodeInt64(7), NodeInt64(4), NodeInt64(1)}},
+		{"post-order stop", (*Tree).WalkPostOrder, map[NodeValue]error{NodeInt64(3): SkipAll}, []NodeValue{NodeInt64(5), NodeInt64(6), NodeInt64(2), NodeInt64(3)}},
+		{"bfs", (*Tree).BFS, nil, []NodeValue{NodeInt64(1), NodeInt64(2), NodeInt64(3), NodeInt64(4), NodeInt64(5), NodeInt64(6), NodeInt64(7)}},
+		{"bfs skip", (*Tree).BFS, map[NodeValue]error{NodeInt64(4): SkipSubtree}, []NodeValue{NodeInt64(1), NodeInt64(2), NodeInt64(3), NodeInt64(4), NodeInt64(5), NodeInt64(6)}},
+	}
+	for _, test := range tests {
+		var order []NodeValue
+		err := test.walk(tr, visit(&order, test.results))
+		if err != nil {
+			t.Errorf("%s: the walk shouldn't return an error: %v", test.name, err)
+		}
+		if !equalValues(order, test.order) {
+			t.Errorf("%s: expected order %v, received %v", test.name, test.order, order)
+		}
+	}
+
+	errTest := errors.New("test error")
+	var order []NodeValue
+	err := tr.Walk(visit(&order, map[NodeValue]error{NodeInt64(6): errTest}))
+	if err != errTest {
+		t.Errorf("the walk should return the error returned by fn, received %v", err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	tr := traversalTree()
+	even := func(t *Tree) bool { return t.Val().(NodeInt64)%2 == 0 }
+
+	found := tr.Find(even)
+	if found == nil || found.Val() != NodeInt64(2) {
+		t.Errorf("the first even node should be 2, received %v", found)
+	}
+	if tr.Find(func(*Tree) bool { return false }) != nil {
+		t.Errorf("no node should be found")
+	}
+
+	var all []NodeValue
+	for _, n := range tr.FindAll(even) {
+		all = append(all, n.Val())
+	}
+	if expected := []NodeValue{NodeInt64(2), NodeInt64(6), NodeInt64(4)}; !equalValues(all, expected) {
+		t.Errorf("expected even nodes %v, received %v", expected, all)
+	}
+}
+
+func TestMeasures(t *testing.T) {
+	tr := traversalTree()
+	t7 := tr.Find(func(t *Tree) bool { return t.Val() == NodeInt64(7) })
+
+	if d := t7.Depth(); d != 2 {
+		t.Errorf("the depth of 7 should be 2, received %d", d)
+	}
+	if d := tr.Depth(); d != 0 {
+		t.Errorf("the depth of the root should be 0, received %d", d)
+	}
+	if h := tr.Height(); h != 2 {
+		t.Errorf("the height of the root should be 2, received %d", h)
+	}
+	if h := t7.Height(); h != 0 {
+		t.Errorf("the height of a leaf should be 0, received %d", h)
+	}
+	if s := tr.Size(); s != 7 {
+		t.Errorf("the size of the tree should be 7, received %d", s)
+	}
+
+	var leaves []NodeValue
+	for _, l := range tr.Leaves() {
+		leaves = append(leaves, l.Val())
+	}
+	if expected := []NodeValue{NodeInt64(5), NodeInt64(6), NodeInt64(3), NodeInt64(7)}; !equalValues(leaves, expected) {
+		t.Errorf("expected leaves %v, received %v", expected, leaves)
+	}
+
+	var path []NodeValue
+	for _, p := range t7.Path() {
+		path = append(path, p.Val())
+	}
+	if expected := []NodeValue{NodeInt64(1), NodeInt64(4), NodeInt64(7)}; !equalValues(path, expected) {
+		t.Errorf("expected path %v, received %v", expected, path)
+	}
+}
+
+func TestLowestCommonAncestor(t *testing.T) {
+	tr := traversalTree()
+	find := func(v NodeInt64) *Tree {
+		return tr.Find(func(t *Tree) bool { return t.Val() == v })
+	}
+
+	tests := []struct {
+		a, b, lca NodeInt64
+	}{
+		{5, 6, 2},
+		{5, 7, 1},
+		{2, 5, 2},
+		{3, 3, 3},
+	}
+	for _, test := range tests {
+		lca, err := LowestCommonAncestor(find(test.a), find(test.b))
+		if err != nil {
+			t.Errorf("%d and %d belong to the same tree: %v", test.a, test.b, err)
+			continue
+		}
+		if lca.Val() != test.lca {
+			t.Errorf("the lowest common ancestor of %d and %d should be %d, received %v", test.a, test.b, test.lca, lca.Val())
+		}
+	}
+
+	_, err := LowestCommonAncestor(tr, NewTree(NodeInt64(1)))
+	if err == nil {
+		t.Errorf("nodes of different trees shouldn't have a common ancestor")
+	}
+}
@@ -2,13 +2,24 @@ package tree
 
 import (
 	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/m1gwings/treedrawer/drawer"
 )
 
 // Tree describes the node of a tree with almost two children.
 type Tree struct {
-	val      NodeValue
-	parent   *Tree
-	children []*Tree
+	val       NodeValue
+	parent    *Tree
+	children  []*Tree
+	collapsed bool
+	// annotation is drawn next to the box of the node, it is not part of its value
+	annotation string
+	// parentAlign and textAlign override the alignments of RenderOptions when they are not nil
+	parentAlign *ParentAlign
+	textAlign   *drawer.Align
 }
 
 // Val returns the value held by the current node of the tree.
@@ -21,6 +32,29 @@ func (t *Tree) SetVal(n NodeValue) {
 	t.val = n
 }
 
+// Collapsed reports whether the children of t are hidden when the tree is drawn.
+func (t *Tree) Collapsed() bool {
+	return t.collapsed
+}
+
+// SetCollapsed sets whether the children of t are hidden when the tree is drawn,
+// hidden children are replaced by a single "… (+N nodes)" leaf.
+func (t *Tree) SetCollapsed(collapsed bool) {
+	t.collapsed = collapsed
+}
+
+// Annotation returns the annotation of t, a short text drawn next to its box which is not part of its value.
+func (t *Tree) Annotation() string {
+	return t.annotation
+}
+
+// SetAnnotation sets the annotation of t, like a count or a status icon, the empty string removes it.
+// Annotations take a single line, so newlines are replaced by spaces.
+// RenderOptions.AnnotationPosition sets where annotations are drawn.
+func (t *Tree) SetAnnotation(annotation string) {
+	t.annotation = strings.ReplaceAll(annotation, "\n", " ")
+}
+
 // Parent returns a pointer to the parent of t.
 // It also returns false if this node is the root of the tree or true otherwise.
 // If this node is the root of the tree the p *Tree returned is equal to t *Tree
@@ -52,6 +86,77 @@ func (t *Tree) AddChild(n NodeValue) (tChild *Tree) {
 	return
 }
 
+// InsertChild adds a child to t with value n at index i, shifting the following children to the right.
+// Returns the child that has been added or an error if i is not between 0 and the number of children.
+func (t *Tree) InsertChild(i int, n NodeValue) (tChild *Tree, err error) {
+	if i < 0 || i > len(t.children) {
+		return nil, fmt.Errorf("can't insert a child with index %d in a node with %d children", i, len(t.children))
+	}
+	tChild = &Tree{val: n, parent: t}
+	t.children = append(t.children, nil)
+	copy(t.children[i+1:], t.children[i:])
+	t.children[i] = tChild
+	return tChild, nil
+}
+
+// RemoveChild removes the i-th child of t together with its subtree.
+// Returns the removed child, which becomes the root of its own tree.
+func (t *Tree) RemoveChild(i int) (tChild *Tree, err error) {
+	tChild, err = t.Child(i)
+	if err != nil {
+		return nil, err
+	}
+	t.children = append(t.children[:i], t.children[i+1:]...)
+	tChild.parent = nil
+	return tChild, nil
+}
+
+// Detach removes t from the children of its parent, t becomes the root of its own tree.
+// Detaching the root of a tree has no effect.
+func (t *Tree) Detach() {
+	if t.parent == nil {
+		return
+	}
+	siblings := t.parent.children
+	for i, sibling := range siblings {
+		if sibling == t {
+			t.parent.children = append(siblings[:i], siblings[i+1:]...)
+			break
+		}
+	}
+	t.parent = nil
+}
+
+// AttachSubtree adds sub as the last child of t, if sub has a parent it is detached first.
+// Returns an error if sub is t or one of its ancestors, since attaching it would create a cycle.
+func (t *Tree) AttachSubtree(sub *Tree) error {
+	for ancestor := t; ancestor != nil; ancestor = ancestor.parent {
+		if ancestor == sub {
+			return fmt.Errorf("can't attach a node below itself")
+		}
+	}
+	sub.Detach()
+	sub.parent = t
+	t.children = append(t.children, sub)
+	return nil
+}
+
+// SwapChildren swaps the i-th and the j-th children of t.
+func (t *Tree) SwapChildren(i, j int) error {
+	if i < 0 || i >= len(t.children) || j < 0 || j >= len(t.children) {
+		return fmt.Errorf("can't swap children with indexes %d %d in a node with %d children", i, j, len(t.children))
+	}
+	t.children[i], t.children[j] = t.children[j], t.children[i]
+	return nil
+}
+
+// SortChildren sorts the children of t according to less, keeping the original order of equal children.
+func (t *Tree) SortChildren(less func(a, b *Tree) bool) {
+	sort.SliceStable(t.children, func(i, j int) bool {
+		return less(t.children[i], t.children[j])
+	})
+}
+
 // NewTree is the default constructor for Tree.
 func NewTree(val NodeValue) *Tree {
 	return &Tree{val: val}
@@ -66,5 +171,9 @@ func (t *Tree) Root() (root *Tree) {
 
 // String returns the string representation of the tree.
 func (t *Tree) String() string {
-	return stringify(t.Root()).String()
+	s, err := t.Render(RenderOptions{})
+	if err != nil {
+		log.Fatal(fmt.Errorf("error while rendering the tree with default options: %v", err))
+	}
+	return s
 }
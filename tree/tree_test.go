@@ -79,3 +79,125 @@ func TestBuiltInNodes(t *testing.T) {
 	tr.AddChild(NodeString("string"))
 	fmt.Println(tr)
 }
+
+// childrenValues returns the values of the children of t.
+func childrenValues(t *Tree) (values []NodeValue) {
+	for _, tChild := range t.Children() {
+		values = append(values, tChild.Val())
+	}
+	return
+}
+
+func TestInsertAndRemoveChild(t *testing.T) {
+	tr := NewTree(NodeInt64(1))
+	tr.AddChild(NodeInt64(2))
+	tr.AddChild(NodeInt64(4))
+
+	tChild, err := tr.InsertChild(1, NodeInt64(3))
+	if err != nil {
+		t.Errorf("1 should be a valid index for inserting a child: %v", err)
+	}
+	if p, _ := tChild.Parent(); p != tr {
+		t.Errorf("the parent of the inserted child should be the root")
+	}
+	_, err = tr.InsertChild(4, NodeInt64(5))
+	if err == nil {
+		t.Errorf("4 shouldn't be a valid index for inserting a child in a node with 3 children")
+	}
+	if v := childrenValues(tr); !equalValues(v, []NodeValue{NodeInt64(2), NodeInt64(3), NodeInt64(4)}) {
+		t.Errorf("expected children 2 3 4, received %v", v)
+	}
+
+	tChild, err = tr.RemoveChild(0)
+	if err != nil {
+		t.Errorf("0 should be a valid index for removing a child: %v", err)
+	}
+	if _, ok := tChild.Parent(); ok {
+		t.Errorf("the removed child should be the root of its own tree")
+	}
+	_, err = tr.RemoveChild(2)
+	if err == nil {
+		t.Errorf("2 shouldn't be a valid index for removing a child in a node with 2 children")
+	}
+	if v := childrenValues(tr); !equalValues(v, []NodeValue{NodeInt64(3), NodeInt64(4)}) {
+		t.Errorf("expected children 3 4, received %v", v)
+	}
+
+	fmt.Println(tr)
+}
+
+func TestAttachAndDetach(t *testing.T) {
+	tr := traversalTree()
+	t2, _ := tr.Child(0)
+	t4, _ := tr.Child(2)
+
+	err := t2.AttachSubtree(tr)
+	if err == nil {
+		t.Errorf("attaching the root below one of its children should create a cycle")
+	}
+	err = t2.AttachSubtree(t2)
+	if err == nil {
+		t.Errorf("attaching a node below itself should create a cycle")
+	}
+
+	err = t2.AttachSubtree(t4)
+	if err != nil {
+		t.Errorf("4 should be movable below 2: %v", err)
+	}
+	if p, _ := t4.Parent(); p != t2 {
+		t.Errorf("the parent of 4 should be 2")
+	}
+	if v := childrenValues(tr); !equalValues(v, []NodeValue{NodeInt64(2), NodeInt64(3)}) {
+		t.Errorf("expected root children 2 3, received %v", v)
+	}
+	if v := childrenValues(t2); !equalValues(v, []NodeValue{NodeInt64(5), NodeInt64(6), NodeInt64(4)}) {
+		t.Errorf("expected children of 2 5 6 4, received %v", v)
+	}
+
+	t2.Detach()
+	if _, ok := t2.Parent(); ok {
+		t.Errorf("the detached node should be the root of its own tree")
+	}
+	if v := childrenValues(tr); !equalValues(v, []NodeValue{NodeInt64(3)}) {
+		t.Errorf("expected root children 3, received %v", v)
+	}
+	tr.Detach()
+
+	fmt.Println(tr)
+	fmt.Println(t2)
+}
+
+func TestReorderChildren(t *testing.T) {
+	tr := NewTree(NodeInt64(0))
+	for _, v := range []NodeInt64{3, 1, 2} {
+		tr.AddChild(v)
+	}
+
+	err := tr.SwapChildren(0, 2)
+	if err != nil {
+		t.Errorf("0 and 2 should be valid indexes for swapping children: %v", err)
+	}
+	if v := childrenValues(tr); !equalValues(v, []NodeValue{NodeInt64(2), NodeInt64(1), NodeInt64(3)}) {
+		t.Errorf("expected children 2 1 3, received %v", v)
+	}
+	err = tr.SwapChildren(0, 3)
+	if err == nil {
+		t.Errorf("3 shouldn't be a valid index for swapping children in a node with 3 children")
+	}
+
+	tr.SortChildren(func(a, b *Tree) bool { return a.Val().(NodeInt64) < b.Val().(NodeInt64) })
+	if v := childrenValues(tr); !equalValues(v, []NodeValue{NodeInt64(1), NodeInt64(2), NodeInt64(3)}) {
+		t.Errorf("expected children 1 2 3, received %v", v)
+	}
+
+	fmt.Println(tr)
+}
+
+func TestLabel(t *testing.T) {
+	if l := Label(NodeString("multi  \nline")); l != "multi\nline" {
+		t.Errorf("expected label multi line without trailing spaces, received %q", l)
+	}
+	if l := Label(NodeInt64(42)); l != "42" {
+		t.Errorf("expected label 42, received %q", l)
+	}
+}
@@ -0,0 +1,155 @@
+// Package treetest provides helpers to test drawings of trees against golden files.
+//
+// Golden files hold the expected drawing, tests run with the -update flag
+// rewrite them with the drawings received:
+//
+//	go test ./mypackage -update
+package treetest
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/m1gwings/treedrawer/tree"
+)
+
+// update is set by the -update flag of the test binary.
+var update = flag.Bool("update", false, "rewrite golden files with the drawings received")
+
+// AssertDrawing checks that the drawing of tr, as returned by tr.String, matches the content of the golden file,
+// after normalizing both with Normalize.
+// When they differ the test fails with a side-by-side diff of the two drawings, built by Diff.
+// When the tests run with the -update flag, the golden file is rewritten with the drawing of tr instead.
+func AssertDrawing(t testing.TB, tr *tree.Tree, golden string) {
+	t.Helper()
+	AssertGolden(t, tr.String(), golden)
+}
+
+// AssertGolden checks that drawing matches the content of the golden file like AssertDrawing does,
+// it can be used for drawings made with options or by other packages.
+func AssertGolden(t testing.TB, drawing, golden string) {
+	t.Helper()
+	received := Normalize(drawing)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Errorf("error while creating the directory of the golden file: %v", err)
+			return
+		}
+		if err := ioutil.WriteFile(golden, []byte(received), 0644); err != nil {
+			t.Errorf("error while updating the golden file: %v", err)
+		}
+		return
+	}
+
+	b, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Errorf("error while reading the golden file, run the tests with -update to create it: %v", err)
+		return
+	}
+	if expected := Normalize(string(b)); expected != received {
+		t.Errorf("the drawing doesn't match %s, run the tests with -update to accept it:\n%s", golden, Diff(expected, received))
+	}
+}
+
+// Normalize removes trailing whitespace from each line of s and the blank lines at its end,
+// it turns "\r\n" into "\n" and it ends s with a single newline, unless s is empty.
+func Normalize(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Diff compares expected and received cell by cell and returns them side by side,
+// followed by a third column with ^ under each cell which differs.
+// Rows containing differences are marked with > and the last line counts the cells which differ.
+// Shorter rows and drawings are padded with spaces, so that missing cells count as spaces.
+func Diff(expected, received string) string {
+	e, r := grid(expected), grid(received)
+	h := len(e)
+	if len(r) > h {
+		h = len(r)
+	}
+	// The columns are at least as wide as their titles
+	w := len("expected")
+	for _, g := range [][][]rune{e, r} {
+		for _, row := range g {
+			if len(row) > w {
+				w = len(row)
+			}
+		}
+	}
+
+	var b strings.Builder
+	columns := func(marker string, left, right, changes string) {
+		line := fmt.Sprintf("%s %s │ %s │ %s", marker, pad(left, w), pad(right, w), changes)
+		b.WriteString(strings.TrimRight(line, " "))
+		b.WriteByte('\n')
+	}
+	columns(" ", "expected", "received", "changes")
+
+	differ := 0
+	for y := 0; y < h; y++ {
+		eRow, rRow := row(e, y, w), row(r, y, w)
+		changes := make([]rune, w)
+		marker := " "
+		for x := range changes {
+			changes[x] = ' '
+			if eRow[x] != rRow[x] {
+				changes[x] = '^'
+				marker = ">"
+				differ++
+			}
+		}
+		columns(marker, string(eRow), string(rRow), string(changes))
+	}
+	fmt.Fprintf(&b, "%d cells differ\n", differ)
+	return b.String()
+}
+
+// grid splits s into rows of runes, without the final newline.
+func grid(s string) [][]rune {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	g := make([][]rune, len(lines))
+	for i, line := range lines {
+		g[i] = []rune(line)
+	}
+	return g
+}
+
+// row returns row y of g padded with spaces to width w, a row of spaces if g has no row y.
+func row(g [][]rune, y, w int) []rune {
+	padded := make([]rune, w)
+	for x := range padded {
+		padded[x] = ' '
+	}
+	if y < len(g) {
+		copy(padded, g[y])
+	}
+	return padded
+}
+
+// pad pads s with spaces to w runes, s is returned unchanged if it is longer.
+func pad(s string, w int) string {
+	if n := len([]rune(s)); n < w {
+		return s + strings.Repeat(" ", w-n)
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package treetest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/m1gwings/treedrawer/tree"
+)
+
+// recorder is a testing.TB which records failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func sampleTree() *tree.Tree {
+	t := tree.NewTree(tree.NodeString("a"))
+	t.AddChild(tree.NodeString("b"))
+	t.AddChild(tree.NodeString("c")).AddChild(tree.NodeString("d"))
+	return t
+}
+
+func TestAssertDrawing(t *testing.T) {
+	AssertDrawing(t, sampleTree(), filepath.Join("testdata", "sample.golden"))
+
+	// The following assertions are expected to fail, even when the golden files are being updated
+	defer func(u bool) { *update = u }(*update)
+	*update = false
+
+	r := &recorder{TB: t}
+	changed := sampleTree()
+	changed.SetVal(tree.NodeString("x"))
+	AssertDrawing(r, changed, filepath.Join("testdata", "sample.golden"))
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "1 cells differ") {
+		t.Errorf("expected a failure with a diff of one cell, received %q", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	AssertDrawing(r, changed, filepath.Join("testdata", "missing.golden"))
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "-update") {
+		t.Errorf("expected a failure suggesting -update, received %q", r.errors)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	defer func(u bool) { *update = u }(*update)
+	*update = true
+
+	golden := filepath.Join(t.TempDir(), "new", "sample.golden")
+	r := &recorder{TB: t}
+	AssertGolden(r, "╭─╮  \n│a│\n╰─╯\n\n", golden)
+	if len(r.errors) != 0 {
+		t.Fatalf("updating should succeed, received %q", r.errors)
+	}
+	b, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("the golden file should be written: %v", err)
+	}
+	if string(b) != "╭─╮\n│a│\n╰─╯\n" {
+		t.Errorf("expected the normalized drawing in the golden file, received %q", b)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		s, expected string
+	}{
+		{"", ""},
+		{"\n  \n", ""},
+		{"a  \r\nb\t\n\n\n", "a\nb\n"},
+		{"  a", "  a\n"},
+	}
+	for _, test := range tests {
+		if n := Normalize(test.s); n != test.expected {
+			t.Errorf("%q: expected %q, received %q", test.s, test.expected, n)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	d := Diff("╭─╮\n│a│\n╰─╯\n", "╭─╮\n│b│\n╰─╯\nx\n")
+	expected := strings.Join([]string{
+		"  expected │ received │ changes",
+		"  ╭─╮      │ ╭─╮      │",
+		"> │a│      │ │b│      │  ^",
+		"  ╰─╯      │ ╰─╯      │",
+		">          │ x        │ ^",
+		"2 cells differ",
+		"",
+	}, "\n")
+	if d != expected {
+		t.Errorf("expected\n%s\nreceived\n%s", expected, d)
+	}
+}
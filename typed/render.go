@@ -0,0 +1,71 @@
+package typed
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/m1gwings/treedrawer/drawer"
+	"github.com/m1gwings/treedrawer/tree"
+)
+
+// Formatter draws a value of type T on the node which holds it.
+type Formatter[T any] func(val T) *drawer.Drawer
+
+// Sprint draws val as the text returned by fmt.Sprint,
+// which is the result of the String method for values satisfying fmt.Stringer.
+// It is the formatter used when none is given.
+func Sprint[T any](val T) *drawer.Drawer {
+	return tree.NodeString(fmt.Sprint(val)).Draw()
+}
+
+// Stringer draws val as the text returned by its String method.
+func Stringer[T fmt.Stringer](val T) *drawer.Drawer {
+	return tree.NodeString(val.String()).Draw()
+}
+
+// formatted satisfies the tree.NodeValue interface by drawing a value with its formatter.
+type formatted[T any] struct {
+	val    T
+	format Formatter[T]
+}
+
+// Draw satisfies the tree.NodeValue interface.
+func (f formatted[T]) Draw() *drawer.Drawer {
+	return f.format(f.val)
+}
+
+// ToTree returns a tree.Tree with the shape of t, in which each value is drawn with format.
+// If format is nil Sprint is used.
+// The returned tree can be used with all the functions of package tree, like Layout or Outline.
+func (t *Tree[T]) ToTree(format Formatter[T]) *tree.Tree {
+	if format == nil {
+		format = Sprint[T]
+	}
+	converted := tree.NewTree(formatted[T]{t.val, format})
+	t.convertChildren(converted, format)
+	return converted
+}
+
+// convertChildren adds to converted the children of t, drawn with format.
+func (t *Tree[T]) convertChildren(converted *tree.Tree, format Formatter[T]) {
+	converted.SetCollapsed(t.collapsed)
+	for _, c := range t.children {
+		c.convertChildren(converted.AddChild(formatted[T]{c.val, format}), format)
+	}
+}
+
+// Render returns the drawing of the tree containing t, with each value drawn with format.
+// If format is nil Sprint is used.
+// See tree.RenderOptions for the meaning of opts.
+func (t *Tree[T]) Render(format Formatter[T], opts tree.RenderOptions) (string, error) {
+	return t.Root().ToTree(format).Render(opts)
+}
+
+// String returns the string representation of the tree, with each value drawn with Sprint.
+func (t *Tree[T]) String() string {
+	s, err := t.Render(nil, tree.RenderOptions{})
+	if err != nil {
+		log.Fatal(fmt.Errorf("error while rendering the tree with default options: %v", err))
+	}
+	return s
+}
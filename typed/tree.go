@@ -0,0 +1,141 @@
+// Package typed provides a tree which holds values of any type,
+// drawn with a formatter chosen when the tree is rendered.
+package typed
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Tree describes the node of a tree holding a value of type T.
+type Tree[T any] struct {
+	val       T
+	parent    *Tree[T]
+	children  []*Tree[T]
+	collapsed bool
+}
+
+// NewTree is the default constructor for Tree.
+func NewTree[T any](val T) *Tree[T] {
+	return &Tree[T]{val: val}
+}
+
+// Val returns the value held by the current node of the tree.
+func (t *Tree[T]) Val() T {
+	return t.val
+}
+
+// SetVal sets the value of the current node of the tree.
+func (t *Tree[T]) SetVal(val T) {
+	t.val = val
+}
+
+// Collapsed reports whether the children of t are hidden when the tree is drawn.
+func (t *Tree[T]) Collapsed() bool {
+	return t.collapsed
+}
+
+// SetCollapsed sets whether the children of t are hidden when the tree is drawn.
+func (t *Tree[T]) SetCollapsed(collapsed bool) {
+	t.collapsed = collapsed
+}
+
+// Parent returns a pointer to the parent of t.
+// It also returns false if this node is the root of the tree or true otherwise.
+// If this node is the root of the tree the p *Tree returned is equal to t *Tree
+func (t *Tree[T]) Parent() (p *Tree[T], ok bool) {
+	if t.parent == nil {
+		return t, false
+	}
+	return t.parent, true
+}
+
+// Children returns a slice of pointers to children of t.
+func (t *Tree[T]) Children() []*Tree[T] {
+	return t.children
+}
+
+// Child returns the i-th child of t.
+func (t *Tree[T]) Child(i int) (child *Tree[T], err error) {
+	if i < 0 || i >= len(t.children) {
+		return nil, fmt.Errorf("there is no child with index %d", i)
+	}
+	return t.children[i], nil
+}
+
+// AddChild adds a child to t with value val.
+// Returns the child that has been added.
+func (t *Tree[T]) AddChild(val T) (tChild *Tree[T]) {
+	tChild = &Tree[T]{val: val, parent: t}
+	t.children = append(t.children, tChild)
+	return
+}
+
+// RemoveChild removes the i-th child of t together with its subtree.
+// Returns the removed child, which becomes the root of its own tree.
+func (t *Tree[T]) RemoveChild(i int) (tChild *Tree[T], err error) {
+	tChild, err = t.Child(i)
+	if err != nil {
+		return nil, err
+	}
+	t.children = append(t.children[:i], t.children[i+1:]...)
+	tChild.parent = nil
+	return tChild, nil
+}
+
+// SortChildren sorts the children of t according to less, keeping the original order of equal children.
+func (t *Tree[T]) SortChildren(less func(a, b T) bool) {
+	sort.SliceStable(t.children, func(i, j int) bool {
+		return less(t.children[i].val, t.children[j].val)
+	})
+}
+
+// Root returns a pointer to the root of the tree
+func (t *Tree[T]) Root() (root *Tree[T]) {
+	for root = t; root.parent != nil; root = root.parent {
+	}
+	return root
+}
+
+// Walk calls fn on t and on each of its descendants in pre-order, until fn returns false.
+func (t *Tree[T]) Walk(fn func(*Tree[T]) bool) {
+	t.walk(fn)
+}
+
+// walk is the recursive step of Walk, it returns false once fn has returned false.
+func (t *Tree[T]) walk(fn func(*Tree[T]) bool) bool {
+	if !fn(t) {
+		return false
+	}
+	for _, c := range t.children {
+		if !c.walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find returns the first node, in pre-order, of the subtree of t holding val.
+// It also returns false if there is no such node.
+func Find[T comparable](t *Tree[T], val T) (found *Tree[T], ok bool) {
+	t.Walk(func(current *Tree[T]) bool {
+		if current.val == val {
+			found, ok = current, true
+		}
+		return !ok
+	})
+	return found, ok
+}
+
+// Equal reports whether a and b have the same shape and hold the same values in the same positions.
+func Equal[T comparable](a, b *Tree[T]) bool {
+	if a.val != b.val || len(a.children) != len(b.children) {
+		return false
+	}
+	for i := range a.children {
+		if !Equal(a.children[i], b.children[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package typed
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/m1gwings/treedrawer/drawer"
+	"github.com/m1gwings/treedrawer/tree"
+)
+
+type point struct{ x, y int }
+
+func (p point) String() string {
+	return "(" + strconv.Itoa(p.x) + ", " + strconv.Itoa(p.y) + ")"
+}
+
+func TestTree(t *testing.T) {
+	tr := NewTree(1)
+	two := tr.AddChild(2)
+	two.AddChild(4)
+	tr.AddChild(3)
+
+	if p, ok := two.Parent(); !ok || p != tr {
+		t.Errorf("the parent of 2 should be the root")
+	}
+	if found, ok := Find(tr, 4); !ok || found.Val() != 4 || found.Root() != tr {
+		t.Errorf("4 should be found below the root")
+	}
+	if _, ok := Find(tr, 5); ok {
+		t.Errorf("5 should not be found")
+	}
+
+	other := NewTree(1)
+	other.AddChild(2).AddChild(4)
+	other.AddChild(3)
+	if !Equal(tr, other) {
+		t.Errorf("trees with the same shape and values should be equal")
+	}
+	other.SortChildren(func(a, b int) bool { return a > b })
+	if Equal(tr, other) {
+		t.Errorf("trees with children in a different order should not be equal")
+	}
+
+	removed, err := tr.RemoveChild(0)
+	if err != nil || removed != two {
+		t.Fatalf("the first child should be removed, received %v %v", removed, err)
+	}
+	if _, ok := two.Parent(); ok || len(tr.Children()) != 1 {
+		t.Errorf("the removed child should become a root")
+	}
+	if _, err := tr.Child(1); err == nil {
+		t.Errorf("expected error for a missing child, received nil")
+	}
+}
+
+func TestRender(t *testing.T) {
+	tr := NewTree(point{0, 0})
+	tr.AddChild(point{1, 2})
+
+	expected, err := tree.NewTree(tree.NodeString("(0, 0)")).Render(tree.RenderOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	leaf := NewTree(point{0, 0})
+	s, err := leaf.Render(Stringer[point], tree.RenderOptions{})
+	if err != nil || s != expected {
+		t.Errorf("expected:\n%s\nreceived:\n%s", expected, s)
+	}
+
+	// Sprint uses the String method too, String renders from the root
+	if tr.Children()[0].String() != tr.String() {
+		t.Errorf("String should draw the whole tree")
+	}
+
+	coordinates := func(p point) *drawer.Drawer {
+		return tree.NodeString(fmt.Sprintf("x=%d\ny=%d", p.x, p.y)).Draw()
+	}
+	s, err = tr.Render(coordinates, tree.RenderOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	converted := tr.ToTree(coordinates)
+	if tree.Label(converted.Children()[0].Val()) != "x=1\ny=2" || converted.String() != s {
+		t.Errorf("the converted tree should be drawn with the formatter, received:\n%s", converted)
+	}
+	fmt.Println(s)
+}
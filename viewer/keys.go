@@ -0,0 +1,56 @@
+package viewer
+
+import (
+	"unicode/utf8"
+)
+
+// key is a key pressed by the user, it is either a rune or one of the special keys below.
+type key rune
+
+// Special keys which are not represented by a single rune.
+const (
+	keyUp key = -(iota + 1)
+	keyDown
+	keyRight
+	keyLeft
+)
+
+// Control keys, represented by the rune sent by the terminal in raw mode.
+const (
+	keyCtrlC     key = 0x03
+	keyEnter     key = '\r'
+	keyEsc       key = 0x1b
+	keyBackspace key = 0x7f
+)
+
+// arrows maps the last byte of the escape sequences sent by arrow keys to the corresponding key.
+var arrows = map[byte]key{
+	'A': keyUp,
+	'B': keyDown,
+	'C': keyRight,
+	'D': keyLeft,
+}
+
+// decodeKeys returns the keys encoded in b, which has been read from a terminal in raw mode.
+// Unknown escape sequences are discarded.
+func decodeKeys(b []byte) (keys []key) {
+	for len(b) > 0 {
+		// Escape sequences start with "\x1b[" or "\x1bO", followed by optional parameters
+		// and terminated by a final byte between '@' and '~'
+		if b[0] == byte(keyEsc) && len(b) >= 3 && (b[1] == '[' || b[1] == 'O') {
+			end := 2
+			for end < len(b)-1 && (b[end] < '@' || b[end] > '~') {
+				end++
+			}
+			if k, ok := arrows[b[end]]; ok {
+				keys = append(keys, k)
+			}
+			b = b[end+1:]
+			continue
+		}
+		r, size := utf8.DecodeRune(b)
+		keys = append(keys, key(r))
+		b = b[size:]
+	}
+	return
+}
@@ -0,0 +1,69 @@
+package viewer
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// terminal is a terminal switched to raw mode, which can be restored to its original mode.
+type terminal struct {
+	f   *os.File
+	old syscall.Termios
+}
+
+// openTerminal switches the terminal f to raw mode: input is available byte by byte,
+// without echo and without signals generated by control keys.
+// Returns an error if f is not a terminal.
+func openTerminal(f *os.File) (*terminal, error) {
+	t := &terminal{f: f}
+	err := t.ioctl(syscall.TCGETS, unsafe.Pointer(&t.old))
+	if err != nil {
+		return nil, fmt.Errorf("error while getting terminal attributes: %v", err)
+	}
+
+	raw := t.old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	err = t.ioctl(syscall.TCSETS, unsafe.Pointer(&raw))
+	if err != nil {
+		return nil, fmt.Errorf("error while switching terminal to raw mode: %v", err)
+	}
+	return t, nil
+}
+
+// restore switches the terminal back to the mode it had before openTerminal.
+func (t *terminal) restore() error {
+	err := t.ioctl(syscall.TCSETS, unsafe.Pointer(&t.old))
+	if err != nil {
+		return fmt.Errorf("error while restoring terminal attributes: %v", err)
+	}
+	return nil
+}
+
+// size returns the number of columns and rows of the terminal.
+func (t *terminal) size() (w, h int, err error) {
+	var ws struct {
+		Row, Col, X, Y uint16
+	}
+	err = t.ioctl(syscall.TIOCGWINSZ, unsafe.Pointer(&ws))
+	if err != nil {
+		return 0, 0, fmt.Errorf("error while getting terminal size: %v", err)
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
+
+// ioctl calls the ioctl system call on the terminal with request req and argument arg.
+func (t *terminal) ioctl(req uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, t.f.Fd(), req, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+//go:build !linux
+// +build !linux
+
+package viewer
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// terminal is a terminal switched to raw mode, which is supported only on Linux.
+type terminal struct{}
+
+// openTerminal returns an error since raw mode is supported only on Linux.
+func openTerminal(f *os.File) (*terminal, error) {
+	return nil, fmt.Errorf("the interactive viewer is not supported on %s", runtime.GOOS)
+}
+
+// restore has nothing to restore since raw mode is supported only on Linux.
+func (t *terminal) restore() error {
+	return nil
+}
+
+// size returns an error since raw mode is supported only on Linux.
+func (t *terminal) size() (w, h int, err error) {
+	return 0, 0, fmt.Errorf("the interactive viewer is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,314 @@
+// Package viewer implements an interactive terminal browser for trees drawn by package tree.
+package viewer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/m1gwings/treedrawer/drawer"
+	"github.com/m1gwings/treedrawer/tree"
+)
+
+// Default size of the screen, used when the size of the terminal is not available.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// Number of columns and rows scrolled by each arrow key.
+const (
+	panX = 4
+	panY = 2
+)
+
+// help is shown in the status line when there is nothing else to show.
+const help = "arrows pan · h/j/k/l move · enter collapse · / search · n next · q quit"
+
+// Escape sequences which turn the reverse video used to highlight the selected node on and off,
+// it swaps the colours of the cells so that it works with every style of boxes.
+const (
+	reverseOn  = "\x1b[7m"
+	reverseOff = "\x1b[27m"
+)
+
+// Viewer is an interactive browser for a tree, which is drawn on the terminal
+// and can be explored with the keyboard.
+type Viewer struct {
+	root     *tree.Tree
+	selected *tree.Tree
+	// opts are the options with which the tree is drawn
+	opts tree.RenderOptions
+	// x and y are the coordinates of the canvas in the up left corner of the screen
+	x, y int
+	// width and height are the dimensions of the screen, the last row is used by the status line
+	width, height int
+	// searching reports whether the user is typing query
+	searching bool
+	query     string
+	// message is shown in the status line until the next key
+	message string
+
+	canvas *drawer.Drawer
+	boxes  map[*tree.Tree]drawer.Rect
+}
+
+// New returns a Viewer for the tree which t belongs to, drawn according to opts, with the root selected.
+// The viewer works on a copy of the tree, so expanding and collapsing nodes doesn't modify t.
+// The drawing is never split into pages, since it can be panned.
+// Returns an error if opts are not valid.
+func New(t *tree.Tree, opts tree.RenderOptions) (*Viewer, error) {
+	v := &Viewer{root: t.Root().Clone(), opts: opts, width: defaultWidth, height: defaultHeight}
+	v.selected = v.root
+	err := v.layout()
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Run shows the viewer on the terminal f and handles the keys pressed by the user until they quit.
+// f must be a terminal, on Linux it gets switched to raw mode until Run returns.
+func (v *Viewer) Run(f *os.File) error {
+	term, err := openTerminal(f)
+	if err != nil {
+		return err
+	}
+	defer term.restore()
+
+	// Hiding the cursor while the viewer is shown
+	io.WriteString(f, "\x1b[?25l")
+	defer io.WriteString(f, "\x1b[H\x1b[2J\x1b[?25h")
+
+	buf := make([]byte, 64)
+	for {
+		if w, h, err := term.size(); err == nil && w > 0 && h > 1 {
+			v.width, v.height = w, h
+		}
+		_, err = io.WriteString(f, v.frame())
+		if err != nil {
+			return fmt.Errorf("error while drawing the viewer: %v", err)
+		}
+		n, err := f.Read(buf)
+		if err != nil {
+			return fmt.Errorf("error while reading keys: %v", err)
+		}
+		for _, k := range decodeKeys(buf[:n]) {
+			if v.handle(k) {
+				return nil
+			}
+		}
+	}
+}
+
+// layout draws the tree again, it must be called every time a node is expanded or collapsed.
+// Returns an error if the options of the viewer are not valid, which New has already checked.
+func (v *Viewer) layout() error {
+	canvas, boxes, err := v.root.Layout(v.opts)
+	if err != nil {
+		return fmt.Errorf("error while drawing the tree: %v", err)
+	}
+	v.canvas, v.boxes = canvas, boxes
+	return nil
+}
+
+// handle updates the viewer according to the key k.
+// Returns true if the user wants to quit.
+func (v *Viewer) handle(k key) (quit bool) {
+	v.message = ""
+	if v.searching {
+		v.handleSearch(k)
+		return false
+	}
+
+	switch k {
+	case 'q', keyCtrlC:
+		return true
+	case keyUp:
+		v.pan(0, -panY)
+	case keyDown:
+		v.pan(0, panY)
+	case keyLeft:
+		v.pan(-panX, 0)
+	case keyRight:
+		v.pan(panX, 0)
+	case keyEnter, ' ':
+		v.toggle()
+	case 'k':
+		if p, ok := v.selected.Parent(); ok {
+			v.selectNode(p)
+		}
+	case 'j':
+		if len(v.selected.Children()) > 0 {
+			v.selectNode(v.selected.Children()[0])
+		}
+	case 'h':
+		v.selectSibling(-1)
+	case 'l':
+		v.selectSibling(1)
+	case '/':
+		v.searching, v.query = true, ""
+	case 'n':
+		v.search()
+	}
+	return false
+}
+
+// handleSearch updates the query according to the key k, while the user is typing it.
+func (v *Viewer) handleSearch(k key) {
+	switch k {
+	case keyEnter:
+		v.searching = false
+		v.search()
+	case keyEsc, keyCtrlC:
+		v.searching = false
+	case keyBackspace:
+		if len(v.query) > 0 {
+			_, size := utf8.DecodeLastRuneInString(v.query)
+			v.query = v.query[:len(v.query)-size]
+		}
+	default:
+		if k >= ' ' {
+			v.query += string(rune(k))
+		}
+	}
+}
+
+// search selects the first node after the selected one, in pre-order, whose label contains the query
+// ignoring case, starting again from the root after the last node.
+func (v *Viewer) search() {
+	if v.query == "" {
+		return
+	}
+	query := strings.ToLower(v.query)
+	var nodes []*tree.Tree
+	v.root.Walk(func(n *tree.Tree) error {
+		nodes = append(nodes, n)
+		return nil
+	})
+	start := 0
+	for i, n := range nodes {
+		if n == v.selected {
+			start = i + 1
+		}
+	}
+	for i := range nodes {
+		n := nodes[(start+i)%len(nodes)]
+		if strings.Contains(strings.ToLower(tree.Label(n.Val())), query) {
+			v.selectNode(n)
+			return
+		}
+	}
+	v.message = fmt.Sprintf("no match for %q", v.query)
+}
+
+// toggle expands the selected node if it is collapsed and collapses it otherwise.
+func (v *Viewer) toggle() {
+	if len(v.selected.Children()) == 0 {
+		return
+	}
+	v.selected.SetCollapsed(!v.selected.Collapsed())
+	// The options have been checked by New, so drawing again can't fail
+	v.layout()
+	v.scrollToSelected()
+}
+
+// selectSibling selects the sibling of the selected node which is delta positions after it.
+func (v *Viewer) selectSibling(delta int) {
+	p, ok := v.selected.Parent()
+	if !ok {
+		return
+	}
+	siblings := p.Children()
+	for i, sibling := range siblings {
+		if sibling == v.selected && i+delta >= 0 && i+delta < len(siblings) {
+			v.selectNode(siblings[i+delta])
+			return
+		}
+	}
+}
+
+// selectNode selects n, expanding its ancestors if it is hidden, and scrolls the screen to it.
+func (v *Viewer) selectNode(n *tree.Tree) {
+	v.selected = n
+	if _, visible := v.boxes[n]; !visible {
+		for _, ancestor := range n.Path() {
+			ancestor.SetCollapsed(false)
+		}
+		// The options have been checked by New, so drawing again can't fail
+		v.layout()
+	}
+	v.scrollToSelected()
+}
+
+// scrollToSelected centres the screen on the selected node if its box is not entirely visible.
+func (v *Viewer) scrollToSelected() {
+	box, ok := v.boxes[v.selected]
+	if !ok {
+		return
+	}
+	screenH := v.height - 1
+	if box.X < v.x || box.X+box.W > v.x+v.width {
+		v.x = box.X + box.W/2 - v.width/2
+	}
+	if box.Y < v.y || box.Y+box.H > v.y+screenH {
+		v.y = box.Y + box.H/2 - screenH/2
+	}
+	v.pan(0, 0)
+}
+
+// pan scrolls the screen by dx columns and dy rows, without going past the edges of the canvas.
+func (v *Viewer) pan(dx, dy int) {
+	w, h := v.canvas.Dimens()
+	v.x = clamp(v.x+dx, 0, w-v.width)
+	v.y = clamp(v.y+dy, 0, h-(v.height-1))
+}
+
+// clamp returns n limited between min and max, min wins if max is smaller than min.
+func clamp(n, min, max int) int {
+	if n > max {
+		n = max
+	}
+	if n < min {
+		n = min
+	}
+	return n
+}
+
+// frame returns the escape sequences and the text which draw the current state of the viewer on the terminal.
+func (v *Viewer) frame() string {
+	screen, err := v.canvas.Viewport(drawer.Rect{X: v.x, Y: v.y, W: v.width, H: v.height - 1})
+	if err != nil {
+		panic(fmt.Errorf("error while extracting the screen from the canvas: %v", err))
+	}
+
+	// Highlighting the box of the selected node, the part of it outside the screen is not drawn
+	rows := strings.Split(strings.TrimSuffix(screen.String(), "\n"), "\n")
+	if box, ok := v.boxes[v.selected]; ok {
+		for y := clamp(box.Y-v.y, 0, len(rows)); y < clamp(box.Y+box.H-v.y, 0, len(rows)); y++ {
+			row := []rune(rows[y])
+			startX, endX := clamp(box.X-v.x, 0, len(row)), clamp(box.X+box.W-v.x, 0, len(row))
+			if startX == endX {
+				break
+			}
+			rows[y] = string(row[:startX]) + reverseOn + string(row[startX:endX]) + reverseOff + string(row[endX:])
+		}
+	}
+
+	var status string
+	switch {
+	case v.searching:
+		status = "/" + v.query
+	case v.message != "":
+		status = v.message
+	default:
+		status = strings.Replace(tree.Label(v.selected.Val()), "\n", " ", -1) + " · " + help
+	}
+	if runes := []rune(status); len(runes) > v.width {
+		status = string(runes[:v.width])
+	}
+
+	return "\x1b[H\x1b[2J" + strings.Join(rows, "\r\n") + "\r\n" + status
+}
@@ -0,0 +1,180 @@
+package viewer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/m1gwings/treedrawer/drawer"
+	"github.com/m1gwings/treedrawer/tree"
+)
+
+// viewerTree returns a tree with root 1, children 2 3 4, 5 and 6 children of 2 and 7 child of 4.
+func viewerTree() *tree.Tree {
+	t := tree.NewTree(tree.NodeInt64(1))
+	t2 := t.AddChild(tree.NodeInt64(2))
+	t.AddChild(tree.NodeInt64(3))
+	t4 := t.AddChild(tree.NodeInt64(4))
+	t2.AddChild(tree.NodeInt64(5))
+	t2.AddChild(tree.NodeInt64(6))
+	t4.AddChild(tree.NodeString("seven"))
+	return t
+}
+
+// newViewer returns a viewer for tr drawn with the default options, failing the test if it can't be created.
+func newViewer(t *testing.T, tr *tree.Tree) *Viewer {
+	v, err := New(tr, tree.RenderOptions{})
+	if err != nil {
+		t.Fatalf("the viewer should be created with the default options: %v", err)
+	}
+	return v
+}
+
+// press sends the keys encoded in s to v, reporting an error if the viewer quits.
+func press(t *testing.T, v *Viewer, s string) {
+	for _, k := range decodeKeys([]byte(s)) {
+		if v.handle(k) {
+			t.Errorf("the viewer shouldn't quit after %q", s)
+		}
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	keys := decodeKeys([]byte("a\x1b[A\x1b[1;5Cé\x1bOD\r\x1b"))
+	expected := []key{'a', keyUp, keyRight, 'é', keyLeft, keyEnter, keyEsc}
+	if len(keys) != len(expected) {
+		t.Fatalf("expected keys %v, received %v", expected, keys)
+	}
+	for i := range keys {
+		if keys[i] != expected[i] {
+			t.Errorf("expected key %d to be %v, received %v", i, expected[i], keys[i])
+		}
+	}
+}
+
+func TestNavigation(t *testing.T) {
+	v := newViewer(t, viewerTree())
+
+	press(t, v, "j")
+	if v.selected.Val() != tree.NodeInt64(2) {
+		t.Errorf("j should select the first child, received %v", v.selected.Val())
+	}
+	press(t, v, "ll")
+	if v.selected.Val() != tree.NodeInt64(4) {
+		t.Errorf("l should select the next sibling, received %v", v.selected.Val())
+	}
+	press(t, v, "lh")
+	if v.selected.Val() != tree.NodeInt64(3) {
+		t.Errorf("h should select the previous sibling, received %v", v.selected.Val())
+	}
+	press(t, v, "k")
+	if v.selected != v.root {
+		t.Errorf("k should select the parent, received %v", v.selected.Val())
+	}
+
+	if !v.handle('q') {
+		t.Errorf("q should quit the viewer")
+	}
+}
+
+func TestCollapseAndSearch(t *testing.T) {
+	original := viewerTree()
+	v := newViewer(t, original)
+
+	press(t, v, "j\r")
+	if !v.selected.Collapsed() {
+		t.Errorf("enter should collapse the selected node")
+	}
+	if _, visible := v.boxes[v.selected.Children()[0]]; visible {
+		t.Errorf("the children of a collapsed node shouldn't be visible")
+	}
+	if original.Children()[0].Collapsed() {
+		t.Errorf("the viewer shouldn't modify the original tree")
+	}
+
+	press(t, v, "/6\r")
+	if v.selected.Val() != tree.NodeInt64(6) {
+		t.Errorf("searching 6 should select 6, received %v", v.selected.Val())
+	}
+	if _, visible := v.boxes[v.selected]; !visible {
+		t.Errorf("searching should expand the ancestors of the match")
+	}
+
+	press(t, v, "/SEV\r")
+	if v.selected.Val() != tree.NodeString("seven") {
+		t.Errorf("searching should ignore case, received %v", v.selected.Val())
+	}
+	press(t, v, "/missing\r")
+	if !strings.Contains(v.frame(), `no match for "missing"`) {
+		t.Errorf("the status line should report that there is no match")
+	}
+}
+
+func TestFrame(t *testing.T) {
+	v := newViewer(t, viewerTree())
+	v.width, v.height = 12, 6
+	v.pan(0, 0)
+
+	frame := v.frame()
+	rows := strings.Split(strings.TrimPrefix(frame, "\x1b[H\x1b[2J"), "\r\n")
+	if len(rows) != 6 {
+		t.Fatalf("the frame should have 6 rows, received %d", len(rows))
+	}
+	if !strings.Contains(frame, reverseOn) {
+		t.Errorf("the selected node should be highlighted")
+	}
+
+	press(t, v, "\x1b[C\x1b[C\x1b[C\x1b[C\x1b[C\x1b[B\x1b[B\x1b[B\x1b[B")
+	w, h := v.canvas.Dimens()
+	if v.x != w-v.width || v.y != h-(v.height-1) {
+		t.Errorf("panning shouldn't go past the edges of the canvas, received %d %d", v.x, v.y)
+	}
+}
+
+func TestFrameStyles(t *testing.T) {
+	for _, style := range []drawer.Style{drawer.RoundedStyle, drawer.SquareStyle, drawer.HeavyStyle, drawer.DoubleStyle, drawer.ASCIIStyle} {
+		v, err := New(viewerTree(), tree.RenderOptions{Style: style})
+		if err != nil {
+			t.Fatalf("the viewer should be created with the style %q: %v", style.TopLeft, err)
+		}
+		// The screen shows the whole canvas, with the status line below it
+		w, h := v.canvas.Dimens()
+		v.width, v.height = w, h+1
+		// Selecting the first child, so that the highlight is not at the edge of the screen
+		press(t, v, "j")
+		box := v.boxes[v.selected]
+
+		frame := v.frame()
+		rows := strings.Split(strings.TrimPrefix(frame, "\x1b[H\x1b[2J"), "\r\n")
+		canvasRows := strings.Split(v.canvas.String(), "\n")
+		for y, row := range rows[:len(rows)-1] {
+			expected := canvasRows[y]
+			if y >= box.Y && y < box.Y+box.H {
+				canvasRow := []rune(canvasRows[y])
+				expected = string(canvasRow[:box.X]) + reverseOn + string(canvasRow[box.X:box.X+box.W]) + reverseOff + string(canvasRow[box.X+box.W:])
+			}
+			if row != expected {
+				t.Errorf("style %q, row %d: expected %q, received %q", style.TopLeft, y, expected, row)
+			}
+		}
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	tr := viewerTree()
+	opts := tree.RenderOptions{Orientation: tree.LeftRight, MaxDepth: 1}
+	v, err := New(tr, opts)
+	if err != nil {
+		t.Fatalf("the viewer should be created with valid options: %v", err)
+	}
+	d, err := tr.Canvas(opts)
+	if err != nil {
+		t.Fatalf("the tree should be drawn with valid options: %v", err)
+	}
+	if v.canvas.String() != d.String() {
+		t.Errorf("the viewer should draw the tree with its options, expected\n%s\nreceived\n%s", d, v.canvas)
+	}
+
+	if _, err := New(tr, tree.RenderOptions{MaxDepth: -1}); err == nil {
+		t.Errorf("the viewer shouldn't be created with invalid options")
+	}
+}